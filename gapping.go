@@ -0,0 +1,108 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"albatross/internal/calculators"
+	"albatross/internal/config"
+	"albatross/internal/logging"
+	"albatross/internal/models"
+	"albatross/internal/parsers"
+	"albatross/internal/processors"
+	"albatross/internal/reader"
+	"albatross/internal/writer"
+)
+
+// runGapping is the gapping command: it reads a session covering the bag, orders the clubs
+// by loft and writes the gaps between consecutive clubs, noting holes and overlaps.
+func runGapping(args []string, output io.Writer) error {
+	flags := flag.NewFlagSet("gapping", flag.ContinueOnError)
+	launchMonitorType := flags.String("type", "", fmt.Sprintf("Launch monitor type (one of %s); detected from the file when omitted", strings.Join(reader.Names(), ", ")))
+	inputFile := flags.String("input", "", "Input file path")
+	profileFile := flags.String("profile", "", "JSON or YAML profile describing the columns of an unsupported launch monitor's CSV export")
+	sheet := flags.String("sheet", "", "XLSX sheet to read, by name or 1-based index; every sheet when omitted")
+	distance := flags.String("distance", "", fmt.Sprintf("Distance to judge gaps on (one of %s); carry when every club reports it, otherwise total, when omitted", strings.Join(writer.Distances, ", ")))
+	maxGap := flags.Float64("max-gap", calculators.DefaultMaxGap, "Gaps larger than this, in the output units, are holes")
+	maxOverlap := flags.Float64("max-overlap", calculators.DefaultMaxOverlap*100, "Percent of the time a club may go as far as the next longer club before they overlap")
+	configFile := flags.String("config", "", "JSON configuration file with the target strategy and per-club overrides")
+	targetStrategy := flags.String("target-strategy", "", fmt.Sprintf("How a club's target is calculated from its distances (one of %s); median when omitted", strings.Join(calculators.TargetStrategies, ", ")))
+	includeFlagged := flags.Bool("include-flagged", false, "Include shots flagged as likely launch monitor misreads")
+	outliers := flags.String("outliers", "", fmt.Sprintf("Leave each club's statistical outliers out (one of %s)", strings.Join(calculators.OutlierDetectors, ", ")))
+	units := flags.String("units", models.UnitsImperial, fmt.Sprintf("Unit system to report distances in (one of %s)", strings.Join(models.UnitSystems, ", ")))
+	inputUnits := flags.String("input-units", "", fmt.Sprintf("Unit system of exports that don't give their units (one of %s); imperial when omitted", strings.Join(models.UnitSystems, ", ")))
+	format := flags.String("format", "text", fmt.Sprintf("Report format (one of %s)", strings.Join(writer.GappingFormats, ", ")))
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *inputFile == "" {
+		return fmt.Errorf("usage: albatross gapping [-type <launch_monitor_type>] -input <input_file>")
+	}
+
+	options := parsers.Options{Sheet: *sheet, InputUnits: *inputUnits}
+	normalizedType, err := resolveLaunchMonitorType(*launchMonitorType, *profileFile, *inputFile, options)
+	if err != nil {
+		return err
+	}
+	result, err := parsers.ReadFile(*inputFile, normalizedType, options)
+	if len(result.Diagnostics) > 0 {
+		logging.Info("Skipped rows that could not be read", logging.Fields{
+			"rowsSkipped": len(result.Diagnostics),
+		})
+	}
+	if err != nil {
+		return fmt.Errorf("processing shot data: %w", err)
+	}
+	shotData := result.Shots
+
+	// Leave misreads and, when asked, outliers out of the clubs' distances
+	processors.ValidateShots(shotData)
+	var detector calculators.OutlierDetector
+	if *outliers != "" {
+		if detector, err = calculators.ParseOutlierDetector(*outliers); err != nil {
+			return err
+		}
+	}
+
+	var settings config.Config
+	if *configFile != "" {
+		if settings, err = config.Load(*configFile); err != nil {
+			return err
+		}
+	}
+	if *targetStrategy == "" {
+		*targetStrategy = settings.TargetStrategy
+	}
+	strategy, err := calculators.ParseTargetStrategy(*targetStrategy)
+	if err != nil {
+		return err
+	}
+	clubStrategies, err := settings.ClubTargetStrategies()
+	if err != nil {
+		return err
+	}
+
+	// Gaps are measured in the output units, so the largest gap allowed is given in them too
+	if err := processors.ConvertUnits(shotData, *units); err != nil {
+		return err
+	}
+
+	gapping, err := calculators.CalculateGapping(shotData, calculators.GappingOptions{
+		Targets: calculators.TargetOptions{
+			IncludeFlagged:  *includeFlagged,
+			ExcludeOutliers: *outliers != "",
+			Strategy:        strategy,
+			ClubStrategies:  clubStrategies,
+		},
+		Distance:   *distance,
+		MaxGap:     *maxGap,
+		MaxOverlap: *maxOverlap / 100,
+		Outliers:   detector,
+	})
+	if err != nil {
+		return err
+	}
+	return writer.WriteGapping(output, gapping, *format)
+}
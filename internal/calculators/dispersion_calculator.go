@@ -0,0 +1,76 @@
+package calculators
+
+import (
+	"math"
+
+	"albatross/internal/models"
+)
+
+// DispersionSigmas lists the sizes of the ellipses CalculateDispersions draws, in standard deviations
+var DispersionSigmas = []float64{1, 2}
+
+// minEllipseShots is the fewest shots an ellipse is drawn for; two shots always lie on a line
+const minEllipseShots = 3
+
+// CalculateDispersions computes the covariance of total distance and side carry for each
+// club type along with its 1σ and 2σ dispersion ellipses. Shots flagged as likely misreads
+// and outliers marked by DetectOutliers are left out, unless that would leave out every one
+// of a club's shots. Dispersions are returned in the order each club first appears in the shot data.
+func CalculateDispersions(shotData []models.ProcessedShotData) []models.ClubDispersion {
+	clubs, clubShots := groupShots(shotData)
+	dispersions := make([]models.ClubDispersion, 0, len(clubs))
+	for _, club := range clubs {
+		dispersions = append(dispersions, calculateDispersion(club, representativeShots(clubShots[club])))
+	}
+	return dispersions
+}
+
+// calculateDispersion computes the dispersion of a club's shots
+func calculateDispersion(club string, shots []models.ProcessedShotData) models.ClubDispersion {
+	meanTotal, meanSide, totalVariance, sideVariance, covariance := totalSideCovariance(shots)
+	dispersion := models.ClubDispersion{
+		Club:          club,
+		Shots:         len(shots),
+		MeanTotal:     meanTotal,
+		MeanSide:      meanSide,
+		TotalVariance: totalVariance,
+		SideVariance:  sideVariance,
+		Covariance:    covariance,
+	}
+	if len(shots) < minEllipseShots {
+		return dispersion
+	}
+
+	// The ellipse's axes follow the eigenvectors of the covariance matrix, with the
+	// square roots of their eigenvalues as the standard deviations along them
+	mean := (totalVariance + sideVariance) / 2
+	spread := math.Hypot((totalVariance-sideVariance)/2, covariance)
+	major, minor := mean+spread, math.Max(mean-spread, 0)
+	angle := math.Atan2(2*covariance, totalVariance-sideVariance) / 2 * 180 / math.Pi
+	for _, sigma := range DispersionSigmas {
+		dispersion.Ellipses = append(dispersion.Ellipses, models.DispersionEllipse{
+			Sigma:       sigma,
+			CenterTotal: meanTotal,
+			CenterSide:  meanSide,
+			SemiMajor:   sigma * math.Sqrt(major),
+			SemiMinor:   sigma * math.Sqrt(minor),
+			Angle:       angle,
+		})
+	}
+	return dispersion
+}
+
+// representativeShots returns a club's shots that aren't flagged as misreads or marked as
+// outliers, or every shot when none are left
+func representativeShots(shots []models.ProcessedShotData) []models.ProcessedShotData {
+	var representative []models.ProcessedShotData
+	for _, shot := range shots {
+		if len(shot.Flags) == 0 && shot.Outlier == "" {
+			representative = append(representative, shot)
+		}
+	}
+	if len(representative) == 0 {
+		return shots
+	}
+	return representative
+}
@@ -0,0 +1,75 @@
+package calculators
+
+import (
+	"math"
+	"testing"
+
+	"albatross/internal/models"
+)
+
+func TestCalculateDispersions(t *testing.T) {
+	shotData := []models.ProcessedShotData{
+		{Club: "7i", Total: 150, Side: 0},
+		{Club: "7i", Total: 160, Side: 0},
+		{Club: "7i", Total: 140, Side: 0},
+		{Club: "7i", Total: 150, Side: 5},
+		{Club: "7i", Total: 150, Side: -5},
+		{Club: "7i", Total: 90, Side: 40, Flags: []string{"zero spin"}},
+		// Longer drives go further right, so the ellipse leans right
+		{Club: "Dr", Total: 250, Side: 0},
+		{Club: "Dr", Total: 260, Side: 10},
+		{Club: "Dr", Total: 240, Side: -10},
+		{Club: "Pw", Total: 120, Side: 2},
+		{Club: "Pw", Total: 125, Side: -2},
+	}
+
+	dispersions := CalculateDispersions(shotData)
+	if len(dispersions) != 3 {
+		t.Fatalf("CalculateDispersions() returned %d clubs, want 3", len(dispersions))
+	}
+
+	ironDispersion := dispersions[0]
+	if ironDispersion.Club != "7i" || ironDispersion.Shots != 5 || ironDispersion.MeanTotal != 150 || ironDispersion.MeanSide != 0 {
+		t.Errorf("Unexpected 7i dispersion: %+v", ironDispersion)
+	}
+	if ironDispersion.TotalVariance != 50 || ironDispersion.SideVariance != 12.5 || ironDispersion.Covariance != 0 {
+		t.Errorf("Unexpected 7i covariance: %+v", ironDispersion)
+	}
+	expectedEllipses := []models.DispersionEllipse{
+		{Sigma: 1, CenterTotal: 150, SemiMajor: math.Sqrt(50), SemiMinor: math.Sqrt(12.5)},
+		{Sigma: 2, CenterTotal: 150, SemiMajor: 2 * math.Sqrt(50), SemiMinor: 2 * math.Sqrt(12.5)},
+	}
+	assertEllipses(t, "7i", ironDispersion.Ellipses, expectedEllipses)
+
+	driverDispersion := dispersions[1]
+	if driverDispersion.Covariance != 100 {
+		t.Errorf("Dr covariance = %v, want 100", driverDispersion.Covariance)
+	}
+	assertEllipses(t, "Dr", driverDispersion.Ellipses, []models.DispersionEllipse{
+		{Sigma: 1, CenterTotal: 250, SemiMajor: math.Sqrt(200), Angle: 45},
+		{Sigma: 2, CenterTotal: 250, SemiMajor: 2 * math.Sqrt(200), Angle: 45},
+	})
+
+	// Two shots always lie on a line, so no ellipse is drawn
+	if wedgeDispersion := dispersions[2]; wedgeDispersion.Shots != 2 || len(wedgeDispersion.Ellipses) != 0 {
+		t.Errorf("Unexpected Pw dispersion: %+v", wedgeDispersion)
+	}
+}
+
+func assertEllipses(t *testing.T, club string, ellipses, expected []models.DispersionEllipse) {
+	t.Helper()
+	if len(ellipses) != len(expected) {
+		t.Fatalf("%s has %d ellipses, want %d", club, len(ellipses), len(expected))
+	}
+	for i, ellipse := range ellipses {
+		want := expected[i]
+		if ellipse.Sigma != want.Sigma || !closeTo(ellipse.CenterTotal, want.CenterTotal) || !closeTo(ellipse.CenterSide, want.CenterSide) ||
+			!closeTo(ellipse.SemiMajor, want.SemiMajor) || !closeTo(ellipse.SemiMinor, want.SemiMinor) || !closeTo(ellipse.Angle, want.Angle) {
+			t.Errorf("%s ellipse %d = %+v, want %+v", club, i, ellipse, want)
+		}
+	}
+}
+
+func closeTo(value, expected float64) bool {
+	return math.Abs(value-expected) < 1e-9
+}
@@ -0,0 +1,121 @@
+package calculators
+
+import (
+	"fmt"
+
+	"albatross/internal/models"
+	"albatross/internal/processors"
+)
+
+// Defaults for judging the gaps between clubs
+const (
+	DefaultMaxGap     = 15.0 // Gaps larger than this many yards leave a hole in the bag
+	DefaultMaxOverlap = 0.25 // Clubs whose shorter club goes as far a quarter of the time overlap
+)
+
+// GappingOptions configures how the gaps between clubs are judged
+type GappingOptions struct {
+	Targets    TargetOptions   // How each club's carry and total targets are calculated; the Distance fields are ignored
+	Distance   string          // The distance gaps are judged on; carry when every club reports it, otherwise total, when empty
+	MaxGap     float64         // Gaps larger than this are holes, so every gap is one when 0; DefaultMaxGap is the usual limit
+	MaxOverlap float64         // Overlaps above this share are flagged, so any overlap is when 0; DefaultMaxOverlap is the usual limit
+	Outliers   OutlierDetector // Marks each club's outliers on the distance gaps are judged on; outliers are left as they are when nil
+}
+
+// CalculateGapping orders the clubs in the shot data from the least lofted to the most and
+// reports the gap between the carry and total targets of each pair of consecutive clubs.
+// Gaps are judged on options.Distance: a gap larger than MaxGap is a hole, and clubs overlap
+// when the shorter club goes at least as far as the longer more than MaxOverlap of the time.
+// When options.Outliers is set, the outliers in the shot data are marked first on that distance.
+// It returns an error for a negative MaxGap or MaxOverlap, an unknown distance or, for carry,
+// a club without carry distances.
+func CalculateGapping(shotData []models.ProcessedShotData, options GappingOptions) (models.Gapping, error) {
+	if options.MaxGap < 0 {
+		return models.Gapping{}, fmt.Errorf("negative maximum gap %g", options.MaxGap)
+	}
+	if options.MaxOverlap < 0 {
+		return models.Gapping{}, fmt.Errorf("negative maximum overlap %g", options.MaxOverlap)
+	}
+
+	clubs, clubShots := groupShots(shotData)
+	processors.SortClubs(clubs)
+
+	distance := options.Distance
+	if distance == "" {
+		distance = DistanceCarry
+		for _, club := range clubs {
+			if len(options.Targets.targetDistances(clubShots[club], DistanceCarry)) == 0 {
+				distance = DistanceTotal
+				break
+			}
+		}
+	}
+	if distance != DistanceTotal && distance != DistanceCarry {
+		return models.Gapping{}, fmt.Errorf("unknown distance '%s'", distance)
+	}
+	if options.Outliers != nil {
+		DetectOutliers(shotData, options.Outliers, TargetOptions{Distance: distance})
+		_, clubShots = groupShots(shotData)
+	}
+
+	gapping := models.Gapping{Distance: distance}
+	for i, club := range clubs {
+		shots := clubShots[club]
+		distances := options.Targets.targetDistances(shots, distance)
+		if len(distances) == 0 {
+			return models.Gapping{}, fmt.Errorf("club '%s' has no %s distances", club, distance)
+		}
+
+		gappingClub := models.GappingClub{Club: club, Shots: len(distances)}
+		gappingClub.Loft, _ = processors.ClubLoft(club)
+		strategy := options.Targets.strategy(club)
+		if carries := options.Targets.targetDistances(shots, DistanceCarry); len(carries) > 0 {
+			gappingClub.Carry = strategy.Target(carries)
+		}
+		gappingClub.Total = strategy.Target(options.Targets.targetDistances(shots, DistanceTotal))
+		gapping.Clubs = append(gapping.Clubs, gappingClub)
+
+		if i == 0 {
+			continue
+		}
+		longer := gapping.Clubs[i-1]
+		gap := models.ClubGap{
+			Longer:   longer.Club,
+			Shorter:  club,
+			TotalGap: longer.Total - gappingClub.Total,
+			Overlap:  overlap(options.Targets.targetDistances(clubShots[longer.Club], distance), distances),
+		}
+		if longer.Carry > 0 && gappingClub.Carry > 0 {
+			gap.CarryGap = longer.Carry - gappingClub.Carry
+		}
+		judged := gap.TotalGap
+		if distance == DistanceCarry {
+			judged = gap.CarryGap
+		}
+		gap.Hole = judged > options.MaxGap
+		gap.Overlapping = gap.Overlap > options.MaxOverlap
+		gapping.Gaps = append(gapping.Gaps, gap)
+	}
+	return gapping, nil
+}
+
+// overlap returns the share of pairs of a longer and a shorter club's distances in which
+// the shorter club goes at least as far, counting ties as half: 0 when every shot with the
+// shorter club falls short of every shot with the longer, and 0.5 when the clubs are alike
+func overlap(longer, shorter []float64) float64 {
+	if len(longer) == 0 || len(shorter) == 0 {
+		return 0
+	}
+	var count float64
+	for _, short := range shorter {
+		for _, long := range longer {
+			switch {
+			case short > long:
+				count++
+			case short == long:
+				count += 0.5
+			}
+		}
+	}
+	return count / float64(len(longer)*len(shorter))
+}
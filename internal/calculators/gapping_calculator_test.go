@@ -0,0 +1,139 @@
+package calculators
+
+import (
+	"reflect"
+	"testing"
+
+	"albatross/internal/models"
+)
+
+func gappingShots() []models.ProcessedShotData {
+	var shotData []models.ProcessedShotData
+	add := func(club string, carries, totals []float64) {
+		for i := range carries {
+			shotData = append(shotData, models.ProcessedShotData{Club: club, Carry: carries[i], Total: totals[i]})
+		}
+	}
+	add("7i", []float64{150, 152, 148}, []float64{158, 160, 162})
+	add("Dr", []float64{222, 225, 228}, []float64{240, 245, 250})
+	add("Pw", []float64{135, 137, 133}, []float64{140, 142, 138})
+	add("5i", []float64{205, 210, 215}, []float64{220, 225, 230})
+	add("3W", []float64{210, 212, 215}, []float64{230, 232, 235})
+	return shotData
+}
+
+// defaultGapping judges gaps with the default limits, as the gapping command does
+var defaultGapping = GappingOptions{MaxGap: DefaultMaxGap, MaxOverlap: DefaultMaxOverlap}
+
+func TestCalculateGapping(t *testing.T) {
+	gapping, err := CalculateGapping(gappingShots(), defaultGapping)
+	if err != nil {
+		t.Fatalf("CalculateGapping failed: %v", err)
+	}
+
+	expectedClubs := []models.GappingClub{
+		{Club: "Dr", Loft: 10.5, Shots: 3, Carry: 225, Total: 245},
+		{Club: "3W", Loft: 15, Shots: 3, Carry: 212, Total: 232},
+		{Club: "5i", Loft: 26, Shots: 3, Carry: 210, Total: 225},
+		{Club: "7i", Loft: 34, Shots: 3, Carry: 150, Total: 160},
+		{Club: "Pw", Loft: 46, Shots: 3, Carry: 135, Total: 140},
+	}
+	expectedGaps := []models.ClubGap{
+		{Longer: "Dr", Shorter: "3W", CarryGap: 13, TotalGap: 13},
+		// The 5-iron goes as far as the 3-wood a third of the time
+		{Longer: "3W", Shorter: "5i", CarryGap: 2, TotalGap: 7, Overlap: 3.0 / 9, Overlapping: true},
+		{Longer: "5i", Shorter: "7i", CarryGap: 60, TotalGap: 65, Hole: true},
+		// Exactly the largest gap allowed
+		{Longer: "7i", Shorter: "Pw", CarryGap: 15, TotalGap: 20},
+	}
+	expected := models.Gapping{Distance: DistanceCarry, Clubs: expectedClubs, Gaps: expectedGaps}
+	if !reflect.DeepEqual(gapping, expected) {
+		t.Errorf("CalculateGapping() = %+v, want %+v", gapping, expected)
+	}
+
+	// Judged on total the wedge gap is a hole too
+	gapping, err = CalculateGapping(gappingShots(), GappingOptions{Distance: DistanceTotal, MaxGap: 18, MaxOverlap: DefaultMaxOverlap})
+	if err != nil {
+		t.Fatalf("CalculateGapping failed: %v", err)
+	}
+	if gapping.Distance != DistanceTotal || !gapping.Gaps[3].Hole || gapping.Gaps[0].Hole {
+		t.Errorf("Unexpected gaps judged on total: %+v", gapping.Gaps)
+	}
+
+	// Zero limits are kept, so every gap is a hole and every overlap is flagged
+	gapping, err = CalculateGapping(gappingShots(), GappingOptions{})
+	if err != nil {
+		t.Fatalf("CalculateGapping failed: %v", err)
+	}
+	for _, gap := range gapping.Gaps {
+		if !gap.Hole || gap.Overlapping != (gap.Overlap > 0) {
+			t.Errorf("Unexpected gap with zero limits: %+v", gap)
+		}
+	}
+
+	invalid := []GappingOptions{
+		{Distance: "apex", MaxGap: DefaultMaxGap, MaxOverlap: DefaultMaxOverlap},
+		{MaxGap: -1, MaxOverlap: DefaultMaxOverlap},
+		{MaxGap: DefaultMaxGap, MaxOverlap: -0.1},
+	}
+	for _, options := range invalid {
+		if _, err := CalculateGapping(gappingShots(), options); err == nil {
+			t.Errorf("Expected error for options %+v, got nil", options)
+		}
+	}
+}
+
+func TestCalculateGappingWithoutCarry(t *testing.T) {
+	shotData := append(gappingShots(), models.ProcessedShotData{Club: "Sw", Total: 100})
+
+	// One club without carry means every gap is judged on total
+	gapping, err := CalculateGapping(shotData, defaultGapping)
+	if err != nil {
+		t.Fatalf("CalculateGapping failed: %v", err)
+	}
+	lastGap := gapping.Gaps[len(gapping.Gaps)-1]
+	if gapping.Distance != DistanceTotal || lastGap.Shorter != "Sw" || lastGap.TotalGap != 40 || lastGap.CarryGap != 0 || !lastGap.Hole {
+		t.Errorf("Unexpected gapping without carry: %+v", gapping)
+	}
+
+	if _, err := CalculateGapping(shotData, GappingOptions{Distance: DistanceCarry, MaxGap: DefaultMaxGap, MaxOverlap: DefaultMaxOverlap}); err == nil {
+		t.Errorf("Expected error for a club without carry, got nil")
+	}
+}
+
+func TestCalculateGappingOutliers(t *testing.T) {
+	// A 7-iron that carried short but ran out is an outlier on carry, which gaps are judged on
+	shotData := append(gappingShots(), models.ProcessedShotData{Club: "7i", Carry: 100, Total: 160})
+	gapping, err := CalculateGapping(shotData, GappingOptions{Targets: TargetOptions{ExcludeOutliers: true}, MaxGap: DefaultMaxGap, MaxOverlap: DefaultMaxOverlap, Outliers: IQRFences{K: 1.5}})
+	if err != nil {
+		t.Fatalf("CalculateGapping failed: %v", err)
+	}
+	if shotData[len(shotData)-1].Outlier == "" {
+		t.Errorf("Short carry wasn't marked as an outlier")
+	}
+	if ironClub := gapping.Clubs[3]; ironClub.Club != "7i" || ironClub.Shots != 3 || ironClub.Carry != 150 {
+		t.Errorf("Unexpected 7i without its outlier: %+v", ironClub)
+	}
+}
+
+func TestOverlap(t *testing.T) {
+	tests := []struct {
+		name     string
+		longer   []float64
+		shorter  []float64
+		expected float64
+	}{
+		{"Separate", []float64{150, 155}, []float64{130, 135}, 0},
+		{"Alike", []float64{150, 155}, []float64{150, 155}, 0.5},
+		{"Reversed", []float64{130}, []float64{150}, 1},
+		{"Empty", nil, []float64{150}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := overlap(tt.longer, tt.shorter); result != tt.expected {
+				t.Errorf("overlap(%v, %v) = %v, want %v", tt.longer, tt.shorter, result, tt.expected)
+			}
+		})
+	}
+}
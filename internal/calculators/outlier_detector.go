@@ -0,0 +1,293 @@
+package calculators
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"albatross/internal/models"
+)
+
+// OutlierDetector finds the shots that don't fit the rest of a club's shots, such as a
+// topped or shanked shot that is a real shot but not a representative one
+type OutlierDetector interface {
+	// Outliers returns why each of a club's shots is an outlier, or an empty reason for
+	// shots that aren't, in the order of shots. Distance is the distance the club is
+	// targeted on, DistanceTotal or DistanceCarry.
+	Outliers(shots []models.ProcessedShotData, distance string) []string
+	// String returns the detector in the form ParseOutlierDetector reads, e.g. "iqr:1.5"
+	String() string
+}
+
+// OutlierDetectors lists the outlier detector names ParseOutlierDetector accepts
+var OutlierDetectors = []string{"iqr[:k]", "mad[:threshold]", "mahalanobis[:threshold]"}
+
+// Default parameters of the outlier detectors
+const (
+	defaultIQRFence             = 1.5 // Tukey's fences
+	defaultMADThreshold         = 3.5 // Iglewicz and Hoaglin's modified z-score cutoff
+	defaultMahalanobisThreshold = 3.0 // About the 99th percentile of the distance for normally distributed shots
+)
+
+// ParseOutlierDetector reads an outlier detector name, ignoring case:
+//   - "iqr" or "iqr:3": distances beyond k (1.5 by default) interquartile ranges
+//     outside the first and third quartiles
+//   - "mad" or "mad:5": distances whose modified z-score, based on the median absolute
+//     deviation, is above the threshold (3.5 by default)
+//   - "mahalanobis" or "mahalanobis:4": shots whose Mahalanobis distance over total distance and
+//     side carry from the club's other shots, adjusted for the number of shots, is above the
+//     threshold (3 by default)
+func ParseOutlierDetector(name string) (OutlierDetector, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	base, parameter, hasParameter := strings.Cut(name, ":")
+
+	var defaultValue float64
+	switch base {
+	case "iqr":
+		defaultValue = defaultIQRFence
+	case "mad":
+		defaultValue = defaultMADThreshold
+	case "mahalanobis":
+		defaultValue = defaultMahalanobisThreshold
+	default:
+		return nil, fmt.Errorf("unknown outlier detector '%s'", name)
+	}
+
+	value := defaultValue
+	if hasParameter {
+		parsed, err := strconv.ParseFloat(parameter, 64)
+		if err != nil || !isFinite(parsed) || parsed <= 0 {
+			return nil, fmt.Errorf("invalid parameter '%s' in outlier detector '%s': must be above 0", parameter, name)
+		}
+		value = parsed
+	}
+
+	switch base {
+	case "iqr":
+		return IQRFences{K: value}, nil
+	case "mad":
+		return MAD{Threshold: value}, nil
+	default:
+		return Mahalanobis{Threshold: value}, nil
+	}
+}
+
+// DetectOutliers marks the outliers among each club's shots, setting each shot's Outlier
+// to why it is one, or clearing it when it isn't. Each club's shots are measured on the
+// distance options target it on, so clubs targeted on carry have their carry outliers
+// marked. It returns the number of outliers.
+func DetectOutliers(shotData []models.ProcessedShotData, detector OutlierDetector, options TargetOptions) int {
+	var clubs []string
+	clubShots := make(map[string][]int)
+	for i, shot := range shotData {
+		if _, seen := clubShots[shot.Club]; !seen {
+			clubs = append(clubs, shot.Club)
+		}
+		clubShots[shot.Club] = append(clubShots[shot.Club], i)
+	}
+
+	outliers := 0
+	for _, club := range clubs {
+		indices := clubShots[club]
+		shots := make([]models.ProcessedShotData, len(indices))
+		for i, index := range indices {
+			shots[i] = shotData[index]
+		}
+		for i, reason := range detector.Outliers(shots, options.clubDistance(club, shots)) {
+			shotData[indices[i]].Outlier = reason
+			if reason != "" {
+				outliers++
+			}
+		}
+	}
+	return outliers
+}
+
+// IQRFences marks distances outside Tukey's fences, K interquartile ranges below the first
+// quartile or above the third, measured on the distance the club is targeted on. Shots without
+// that distance aren't outliers, and clubs with fewer than four shots that have it have none.
+type IQRFences struct {
+	K float64 // How many interquartile ranges outside the quartiles the fences are
+}
+
+func (detector IQRFences) Outliers(shots []models.ProcessedShotData, distance string) []string {
+	reasons := make([]string, len(shots))
+	distances := measuredDistances(shots, distance)
+	if len(distances) < 4 {
+		return reasons
+	}
+
+	firstQuartile := Percentile{Percent: 25}.Target(distances)
+	thirdQuartile := Percentile{Percent: 75}.Target(distances)
+	iqr := thirdQuartile - firstQuartile
+	lower, upper := firstQuartile-detector.K*iqr, thirdQuartile+detector.K*iqr
+	for i, shot := range shots {
+		value, ok := shotDistance(shot, distance)
+		switch {
+		case !ok:
+		case value < lower:
+			reasons[i] = fmt.Sprintf("%s %.1f below IQR fence %.1f", distanceName(distance), value, lower)
+		case value > upper:
+			reasons[i] = fmt.Sprintf("%s %.1f above IQR fence %.1f", distanceName(distance), value, upper)
+		}
+	}
+	return reasons
+}
+
+func (detector IQRFences) String() string {
+	return "iqr:" + strconv.FormatFloat(detector.K, 'f', -1, 64)
+}
+
+// MAD marks distances whose modified z-score, their distance from the median in median
+// absolute deviations scaled to match standard deviations, is above Threshold, measured on
+// the distance the club is targeted on. When more than half the shots share the median, the
+// mean absolute deviation is used instead. Shots without that distance aren't outliers, and
+// clubs with fewer than three shots that have it have none.
+type MAD struct {
+	Threshold float64 // The modified z-score above which a shot is an outlier
+}
+
+func (detector MAD) Outliers(shots []models.ProcessedShotData, distance string) []string {
+	reasons := make([]string, len(shots))
+	distances := measuredDistances(shots, distance)
+	if len(distances) < 3 {
+		return reasons
+	}
+
+	median := calculateMedian(append([]float64(nil), distances...))
+	deviations := make([]float64, len(distances))
+	for i, value := range distances {
+		deviations[i] = math.Abs(value - median)
+	}
+	// Scale factors make both deviations estimate the standard deviation of normally distributed shots
+	scale := calculateMedian(append([]float64(nil), deviations...)) / 0.6745
+	if scale == 0 {
+		scale = calculateMean(deviations) * 1.253314
+	}
+	if scale == 0 {
+		return reasons
+	}
+
+	for i, shot := range shots {
+		value, ok := shotDistance(shot, distance)
+		if !ok {
+			continue
+		}
+		if score := math.Abs(value-median) / scale; score > detector.Threshold {
+			reasons[i] = fmt.Sprintf("%s %.1f modified z-score %.1f above %s", distanceName(distance), value, score, formatThreshold(detector.Threshold))
+		}
+	}
+	return reasons
+}
+
+func (detector MAD) String() string {
+	return "mad:" + strconv.FormatFloat(detector.Threshold, 'f', -1, 64)
+}
+
+// Mahalanobis marks shots whose Mahalanobis distance over total distance and side carry
+// is above Threshold. Each shot is measured against the mean and covariance of the club's
+// other shots, so a single wild shot can't widen the spread it is judged by. With only a
+// handful of other shots their spread is uncertain, so the distance is adjusted for the
+// number of shots: it is the distance a shot equally unlikely would have if the spread
+// were known exactly, which the plain distance approaches as clubs get more shots. Clubs
+// with fewer than four shots, or whose other shots all lie on a line, have no outliers.
+// Shots are measured on total distance whatever distance the club is targeted on, as that
+// is the distance the dispersion ellipses are drawn on.
+type Mahalanobis struct {
+	Threshold float64 // The adjusted distance, in standard deviations, above which a shot is an outlier
+}
+
+func (detector Mahalanobis) Outliers(shots []models.ProcessedShotData, _ string) []string {
+	reasons := make([]string, len(shots))
+	if len(shots) < 4 {
+		return reasons
+	}
+
+	others := make([]models.ProcessedShotData, 0, len(shots)-1)
+	for i, shot := range shots {
+		others = append(append(others[:0], shots[:i]...), shots[i+1:]...)
+		distance, ok := mahalanobisDistance(shot, others)
+		if !ok {
+			continue
+		}
+		if adjusted := adjustMahalanobisDistance(distance, len(others)); adjusted > detector.Threshold {
+			reasons[i] = fmt.Sprintf("Mahalanobis distance %.1f (%.1f adjusted for %d shots) above %s", distance, adjusted, len(shots), formatThreshold(detector.Threshold))
+		}
+	}
+	return reasons
+}
+
+func (detector Mahalanobis) String() string {
+	return "mahalanobis:" + strconv.FormatFloat(detector.Threshold, 'f', -1, 64)
+}
+
+// mahalanobisDistance returns the Mahalanobis distance of a shot's total distance and side
+// carry from the mean of others, reporting false when their covariance is singular
+func mahalanobisDistance(shot models.ProcessedShotData, others []models.ProcessedShotData) (float64, bool) {
+	meanTotal, meanSide, varTotal, varSide, covariance := totalSideCovariance(others)
+	determinant := varTotal*varSide - covariance*covariance
+	if determinant <= 1e-9 {
+		return 0, false
+	}
+
+	dTotal, dSide := shot.Total-meanTotal, shot.Side-meanSide
+	squared := (dTotal*dTotal*varSide - 2*dTotal*dSide*covariance + dSide*dSide*varTotal) / determinant
+	return math.Sqrt(squared), true
+}
+
+// adjustMahalanobisDistance converts the distance of a shot from the mean of m other shots into
+// the distance with the same probability under a known spread. For two dimensions the shot's
+// Hotelling T-squared follows an F distribution whose tail probability has a closed form,
+// as does the chi-squared distance with that tail probability.
+func adjustMahalanobisDistance(distance float64, m int) float64 {
+	tSquared := distance * distance * float64(m) / float64(m+1)
+	return math.Sqrt(float64(m-2) * math.Log1p(tSquared/float64(m-1)))
+}
+
+// totalSideCovariance returns the means of total distance and side carry over shots, along with
+// their sample variances and covariance
+func totalSideCovariance(shots []models.ProcessedShotData) (meanTotal, meanSide, varTotal, varSide, covariance float64) {
+	n := float64(len(shots))
+	for _, shot := range shots {
+		meanTotal += shot.Total
+		meanSide += shot.Side
+	}
+	meanTotal /= n
+	meanSide /= n
+	if len(shots) < 2 {
+		return meanTotal, meanSide, 0, 0, 0
+	}
+
+	for _, shot := range shots {
+		dTotal, dSide := shot.Total-meanTotal, shot.Side-meanSide
+		varTotal += dTotal * dTotal
+		varSide += dSide * dSide
+		covariance += dTotal * dSide
+	}
+	return meanTotal, meanSide, varTotal / (n - 1), varSide / (n - 1), covariance / (n - 1)
+}
+
+// measuredDistances returns the distances of the shots that have the given distance in a new slice
+func measuredDistances(shots []models.ProcessedShotData, distance string) []float64 {
+	var distances []float64
+	for _, shot := range shots {
+		if value, ok := shotDistance(shot, distance); ok {
+			distances = append(distances, value)
+		}
+	}
+	return distances
+}
+
+// distanceName names the distance an outlier was measured on for its reason, e.g. "carry"
+func distanceName(distance string) string {
+	if distance == DistanceCarry {
+		return DistanceCarry
+	}
+	return DistanceTotal
+}
+
+// formatThreshold formats a detector's threshold without trailing zeros, e.g. 3.5 or 3
+func formatThreshold(threshold float64) string {
+	return strconv.FormatFloat(threshold, 'f', -1, 64)
+}
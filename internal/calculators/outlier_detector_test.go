@@ -0,0 +1,161 @@
+package calculators
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"albatross/internal/models"
+)
+
+// toppedSevenIron is six 7-iron shots, the last of them topped
+func toppedSevenIron() []models.ProcessedShotData {
+	return []models.ProcessedShotData{
+		{Club: "7i", Total: 150, Side: 2},
+		{Club: "7i", Total: 152, Side: -1},
+		{Club: "7i", Total: 148, Side: 3},
+		{Club: "7i", Total: 151, Side: 0},
+		{Club: "7i", Total: 149, Side: -2},
+		{Club: "7i", Total: 95, Side: -10},
+	}
+}
+
+func TestOutlierDetectors(t *testing.T) {
+	tests := []struct {
+		name     string
+		detector OutlierDetector
+		expected string
+	}{
+		{"IQR", IQRFences{K: 1.5}, "total 95.0 below IQR fence 144.5"},
+		{"MAD", MAD{Threshold: 3.5}, "total 95.0 modified z-score 24.5 above 3.5"},
+		{"Mahalanobis", Mahalanobis{Threshold: 3}, "Mahalanobis distance 42.0 (4.2 adjusted for 6 shots) above 3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shots := toppedSevenIron()
+			reasons := tt.detector.Outliers(shots, DistanceTotal)
+			expected := []string{"", "", "", "", "", tt.expected}
+			if !reflect.DeepEqual(reasons, expected) {
+				t.Errorf("Outliers() = %q, want %q", reasons, expected)
+			}
+
+			// Too few shots to tell an outlier from the spread
+			if reasons := tt.detector.Outliers(shots[4:], DistanceTotal); !reflect.DeepEqual(reasons, []string{"", ""}) {
+				t.Errorf("Outliers() of two shots = %q, want none", reasons)
+			}
+		})
+	}
+}
+
+func TestMADSharedMedian(t *testing.T) {
+	// More than half the shots share the median, so the median absolute deviation is zero
+	shots := []models.ProcessedShotData{{Total: 150}, {Total: 150}, {Total: 150}, {Total: 150}, {Total: 120}}
+	reasons := MAD{Threshold: 3.5}.Outliers(shots, DistanceTotal)
+	if reasons[4] == "" || strings.Join(reasons[:4], "") != "" {
+		t.Errorf("Outliers() = %q, want only the last shot", reasons)
+	}
+}
+
+func TestMahalanobisSingularCovariance(t *testing.T) {
+	// Shots without side carry have no spread across the target line to measure against
+	shots := []models.ProcessedShotData{{Total: 150}, {Total: 152}, {Total: 148}, {Total: 95}}
+	if reasons := (Mahalanobis{Threshold: 3}).Outliers(shots, DistanceTotal); strings.Join(reasons, "") != "" {
+		t.Errorf("Outliers() = %q, want none", reasons)
+	}
+}
+
+func TestDetectOutliers(t *testing.T) {
+	shotData := append(toppedSevenIron(),
+		models.ProcessedShotData{Club: "Dr", Total: 250, Side: 5},
+		models.ProcessedShotData{Club: "Dr", Total: 180, Side: 30, Outlier: "read back in from a processed file"},
+	)
+
+	if count := DetectOutliers(shotData, IQRFences{K: 1.5}, TargetOptions{}); count != 1 {
+		t.Errorf("DetectOutliers() = %d, want 1", count)
+	}
+	if shotData[5].Outlier == "" {
+		t.Errorf("Topped shot wasn't marked as an outlier")
+	}
+	if shotData[7].Outlier != "" {
+		t.Errorf("Outlier from an earlier run wasn't cleared: %q", shotData[7].Outlier)
+	}
+}
+
+func TestDetectOutliersOnCarry(t *testing.T) {
+	// The mishit carried short but ran out to a typical total, and the last shot has no carry
+	shotData := []models.ProcessedShotData{
+		{Club: "7i", Type: "Approach", Carry: 140, Total: 150},
+		{Club: "7i", Type: "Approach", Carry: 142, Total: 152},
+		{Club: "7i", Type: "Approach", Carry: 138, Total: 148},
+		{Club: "7i", Type: "Approach", Carry: 141, Total: 151},
+		{Club: "7i", Type: "Approach", Carry: 110, Total: 149},
+		{Club: "7i", Type: "Approach", Total: 95},
+	}
+	options := TargetOptions{TypeDistances: map[string]string{"Approach": DistanceCarry}}
+
+	for _, detector := range []OutlierDetector{IQRFences{K: 1.5}, MAD{Threshold: 3.5}} {
+		if count := DetectOutliers(shotData, detector, options); count != 1 {
+			t.Errorf("%s: DetectOutliers() = %d, want 1", detector, count)
+		}
+		if !strings.HasPrefix(shotData[4].Outlier, "carry 110.0") || shotData[5].Outlier != "" {
+			t.Errorf("%s: unexpected outliers on carry: %q, %q", detector, shotData[4].Outlier, shotData[5].Outlier)
+		}
+	}
+
+	// On total the shot without carry is the outlier instead
+	DetectOutliers(shotData, IQRFences{K: 1.5}, TargetOptions{})
+	if shotData[4].Outlier != "" || !strings.HasPrefix(shotData[5].Outlier, "total 95.0") {
+		t.Errorf("Unexpected outliers on total: %q, %q", shotData[4].Outlier, shotData[5].Outlier)
+	}
+}
+
+func TestParseOutlierDetector(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected OutlierDetector
+		wantErr  bool
+	}{
+		{"iqr", IQRFences{K: 1.5}, false},
+		{"IQR:3", IQRFences{K: 3}, false},
+		{"mad", MAD{Threshold: 3.5}, false},
+		{"mad:5", MAD{Threshold: 5}, false},
+		{" mahalanobis ", Mahalanobis{Threshold: 3}, false},
+		{"mahalanobis:2.5", Mahalanobis{Threshold: 2.5}, false},
+		{"iqr:0", nil, true},
+		{"mad:many", nil, true},
+		{"iqr:nan", nil, true},
+		{"mahalanobis:inf", nil, true},
+		{"zscore", nil, true},
+		{"", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			detector, err := ParseOutlierDetector(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseOutlierDetector(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(detector, tt.expected) {
+				t.Errorf("ParseOutlierDetector(%q) = %v, want %v", tt.name, detector, tt.expected)
+			}
+			if detector != nil {
+				if parsed, err := ParseOutlierDetector(detector.String()); err != nil || parsed != detector {
+					t.Errorf("ParseOutlierDetector(%q) = %v, %v, want %v", detector.String(), parsed, err, detector)
+				}
+			}
+		})
+	}
+}
+
+func TestAdjustMahalanobisDistance(t *testing.T) {
+	// A handful of shots can't pin down the spread, so their distances are discounted
+	if adjusted := adjustMahalanobisDistance(5, 5); adjusted >= 3 {
+		t.Errorf("adjustMahalanobisDistance(5, 5) = %v, want below 3", adjusted)
+	}
+	// With plenty of shots the spread is known and the distance barely changes
+	if adjusted := adjustMahalanobisDistance(3, 10000); math.Abs(adjusted-3) > 0.01 {
+		t.Errorf("adjustMahalanobisDistance(3, 10000) = %v, want about 3", adjusted)
+	}
+}
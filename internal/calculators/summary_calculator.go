@@ -0,0 +1,113 @@
+package calculators
+
+import (
+	"math"
+
+	"albatross/internal/models"
+)
+
+// SummarizeClubs aggregates the shot data for each club type into a ClubSummary.
+// Summaries are returned in the order each club first appears in the shot data.
+// Targets are taken from the shots, so CalculateTargets should be run first.
+func SummarizeClubs(shotData []models.ProcessedShotData) []models.ClubSummary {
+	clubs, clubShots := groupShots(shotData)
+
+	summaries := make([]models.ClubSummary, 0, len(clubs))
+	for _, club := range clubs {
+		shots := clubShots[club]
+		totals := make([]float64, len(shots))
+		sides := make([]float64, len(shots))
+		var carries []float64
+		flagged, outliers := 0, 0
+		for i, shot := range shots {
+			totals[i] = shot.Total
+			sides[i] = shot.Side
+			// Not every launch monitor reports carry, so shots without it are left out
+			if shot.Carry > 0 {
+				carries = append(carries, shot.Carry)
+			}
+			if len(shot.Flags) > 0 {
+				flagged++
+			}
+			if shot.Outlier != "" {
+				outliers++
+			}
+		}
+
+		summaries = append(summaries, models.ClubSummary{
+			Club:           club,
+			Type:           shots[0].Type,
+			Shots:          len(shots),
+			Flagged:        flagged,
+			Outliers:       outliers,
+			Target:         shots[0].Target,
+			TargetDistance: shots[0].TargetDistance,
+			MeanTotal:      calculateMean(totals),
+			MedianTotal:    calculateMedian(totals),
+			MeanCarry:      calculateMean(carries),
+			MedianCarry:    calculateMedian(carries),
+			MeanSide:       calculateMean(sides),
+			SideStdDev:     calculateStdDev(sides),
+
+			MeanBallSpeed:   meanReported(shots, func(shot models.ProcessedShotData) float64 { return shot.BallSpeed }),
+			MeanClubSpeed:   meanReported(shots, func(shot models.ProcessedShotData) float64 { return shot.ClubSpeed }),
+			MeanSmashFactor: meanReported(shots, func(shot models.ProcessedShotData) float64 { return shot.SmashFactor }),
+			MeanLaunchAngle: meanLaunchAngle(shots),
+			MeanSpinRate:    meanReported(shots, func(shot models.ProcessedShotData) float64 { return shot.SpinRate }),
+		})
+	}
+	return summaries
+}
+
+// meanReported computes the mean of a speed, smash factor or spin measurement over the shots
+// that report it. Launch monitors leave measurements they don't take at zero, and these are
+// never zero for a measured shot, so zero values are left out.
+func meanReported(shots []models.ProcessedShotData, measurement func(models.ProcessedShotData) float64) float64 {
+	var values []float64
+	for _, shot := range shots {
+		if value := measurement(shot); value != 0 {
+			values = append(values, value)
+		}
+	}
+	return calculateMean(values)
+}
+
+// meanLaunchAngle computes the mean launch angle over the shots that report it. A launch angle
+// of 0° is a real measurement, so a zero angle only counts as unreported when the shot doesn't
+// report a ball speed either, which launch monitors measure along with the launch angle.
+func meanLaunchAngle(shots []models.ProcessedShotData) float64 {
+	var angles []float64
+	for _, shot := range shots {
+		if shot.LaunchAngle != 0 || shot.BallSpeed != 0 {
+			angles = append(angles, shot.LaunchAngle)
+		}
+	}
+	return calculateMean(angles)
+}
+
+// calculateMean computes the arithmetic mean of a slice of float64 numbers.
+// If the input slice is empty, it returns 0.
+func calculateMean(numbers []float64) float64 {
+	if len(numbers) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, number := range numbers {
+		sum += number
+	}
+	return sum / float64(len(numbers))
+}
+
+// calculateStdDev computes the sample standard deviation of a slice of float64 numbers.
+// It returns 0 when there are fewer than two numbers.
+func calculateStdDev(numbers []float64) float64 {
+	if len(numbers) < 2 {
+		return 0
+	}
+	mean := calculateMean(numbers)
+	sumSquares := 0.0
+	for _, number := range numbers {
+		sumSquares += (number - mean) * (number - mean)
+	}
+	return math.Sqrt(sumSquares / float64(len(numbers)-1))
+}
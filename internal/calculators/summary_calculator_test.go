@@ -0,0 +1,116 @@
+package calculators
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"albatross/internal/models"
+)
+
+func TestSummarizeClubs(t *testing.T) {
+	shotData := []models.ProcessedShotData{
+		{Club: "7i", Type: "Approach", Target: 155, Total: 150, Side: -2},
+		{Club: "Dr", Type: "Tee", Target: 255, Total: 250, Side: 10},
+		{Club: "7i", Type: "Approach", Target: 155, Total: 160, Side: 4},
+		{Club: "7i", Type: "Approach", Target: 155, Total: 155, Side: 1},
+		{Club: "Dr", Type: "Tee", Target: 255, Total: 260, Side: 0},
+	}
+
+	expected := []models.ClubSummary{
+		{Club: "7i", Type: "Approach", Shots: 3, Target: 155, MeanTotal: 155, MedianTotal: 155, MeanSide: 1, SideStdDev: 3},
+		{Club: "Dr", Type: "Tee", Shots: 2, Target: 255, MeanTotal: 255, MedianTotal: 255, MeanSide: 5, SideStdDev: math.Sqrt(50)},
+	}
+
+	result := SummarizeClubs(shotData)
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("SummarizeClubs() = %+v, want %+v", result, expected)
+	}
+
+	// Summarizing must not reorder the shot data
+	if shotData[0].Total != 150 || shotData[2].Total != 160 {
+		t.Errorf("SummarizeClubs() modified the input: %+v", shotData)
+	}
+
+	withCarry := SummarizeClubs([]models.ProcessedShotData{
+		{Club: "Pw", Carry: 90, Total: 95},
+		{Club: "Pw", Carry: 0, Total: 97},
+		{Club: "Pw", Carry: 94, Total: 99},
+	})
+	if withCarry[0].MeanCarry != 92 || withCarry[0].MedianCarry != 92 {
+		t.Errorf("Expected carry statistics over shots with a carry distance, got %+v", withCarry[0])
+	}
+
+	withLaunchData := SummarizeClubs([]models.ProcessedShotData{
+		{Club: "Dr", Total: 250, BallSpeed: 150, ClubSpeed: 100, SmashFactor: 1.5, LaunchAngle: 12, SpinRate: 2500},
+		{Club: "Dr", Total: 260, BallSpeed: 156, ClubSpeed: 104, SmashFactor: 1.5, LaunchAngle: 14, SpinRate: 2300},
+		{Club: "Dr", Total: 255},
+	})
+	if summary := withLaunchData[0]; summary.MeanBallSpeed != 153 || summary.MeanClubSpeed != 102 || summary.MeanSmashFactor != 1.5 ||
+		summary.MeanLaunchAngle != 13 || summary.MeanSpinRate != 2400 {
+		t.Errorf("Expected launch data means over shots that report them, got %+v", summary)
+	}
+
+	// A wedge launched level is a real 0° launch angle, while a shot without ball speed didn't report one
+	withLevelLaunch := SummarizeClubs([]models.ProcessedShotData{
+		{Club: "Pw", Total: 60, BallSpeed: 70, LaunchAngle: 0, SpinRate: 5000},
+		{Club: "Pw", Total: 95, BallSpeed: 90, LaunchAngle: 24, SpinRate: 8000},
+		{Club: "Pw", Total: 90},
+	})
+	if summary := withLevelLaunch[0]; summary.MeanLaunchAngle != 12 || summary.MeanBallSpeed != 80 {
+		t.Errorf("Expected a 0° launch angle in the mean, got %+v", summary)
+	}
+
+	withFlags := SummarizeClubs([]models.ProcessedShotData{
+		{Club: "7i", Total: 150},
+		{Club: "7i", Total: 90, Flags: []string{"zero spin"}},
+	})
+	if withFlags[0].Flagged != 1 {
+		t.Errorf("Expected 1 flagged shot, got %+v", withFlags[0])
+	}
+
+	if result := SummarizeClubs(nil); len(result) != 0 {
+		t.Errorf("SummarizeClubs(nil) = %+v, want empty", result)
+	}
+}
+
+func TestCalculateMean(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []float64
+		expected float64
+	}{
+		{"Empty slice", []float64{}, 0},
+		{"Single element", []float64{5}, 5},
+		{"Multiple elements", []float64{1, 2, 3, 6}, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := calculateMean(tt.input); result != tt.expected {
+				t.Errorf("calculateMean(%v) = %v, want %v", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCalculateStdDev(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []float64
+		expected float64
+	}{
+		{"Empty slice", []float64{}, 0},
+		{"Single element", []float64{5}, 0},
+		{"Identical elements", []float64{3, 3, 3}, 0},
+		{"Sample deviation", []float64{2, 4, 4, 4, 5, 5, 7, 9}, math.Sqrt(32.0 / 7.0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := calculateStdDev(tt.input); math.Abs(result-tt.expected) > 1e-9 {
+				t.Errorf("calculateStdDev(%v) = %v, want %v", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
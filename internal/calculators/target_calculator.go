@@ -2,32 +2,182 @@
 package calculators
 
 import (
+	"fmt"
 	"sort"
 
 	"albatross/internal/models"
 )
 
+// Distances targets can be calculated from
+const (
+	DistanceTotal = "total"
+	DistanceCarry = "carry"
+)
+
+// TargetOptions configures how targets are calculated
+type TargetOptions struct {
+	Distance        string                    // The distance targets are calculated from, DistanceTotal or DistanceCarry; total when empty
+	TypeDistances   map[string]string         // Distances for shot types, keyed by type (e.g. "Approach": DistanceCarry)
+	ClubDistances   map[string]string         // Distances for particular clubs, keyed by normalized club type, overriding TypeDistances
+	IncludeFlagged  bool                      // Include shots flagged as likely misreads, which are left out by default
+	ExcludeOutliers bool                      // Leave out shots marked as outliers by DetectOutliers, which are included by default
+	Strategy        TargetStrategy            // How a club's distances become its target; the median when nil
+	ClubStrategies  map[string]TargetStrategy // Strategies for particular clubs, keyed by normalized club type (e.g. "Dr")
+}
+
 // CalculateTargets computes the median target distance for each club type
 // and updates the Target field in each ProcessedShotData struct.
+// Shots flagged as likely misreads are left out of the median.
 // This function modifies the input slice in-place.
 func CalculateTargets(shotData *[]models.ProcessedShotData) {
-	// Group shots by club type
-	clubShots := make(map[string][]float64)
-	for _, shot := range *shotData {
-		clubShots[shot.Club] = append(clubShots[shot.Club], shot.Total)
+	calculateTargets(shotData, TargetOptions{})
+}
+
+// CalculateCarryTargets computes the median carry distance for each club type
+// and updates the Target field in each ProcessedShotData struct.
+// Shots without a carry distance or flagged as likely misreads are left out of the median,
+// and clubs without any carry distances are targeted on total distance instead.
+// This function modifies the input slice in-place.
+func CalculateCarryTargets(shotData *[]models.ProcessedShotData) {
+	calculateTargets(shotData, TargetOptions{Distance: DistanceCarry})
+}
+
+// CalculateTargetsWithOptions computes the target for each club type as configured by options
+// and updates the Target and TargetDistance fields in each ProcessedShotData struct.
+// It returns an error for an unknown distance. This function modifies the input slice in-place.
+func CalculateTargetsWithOptions(shotData *[]models.ProcessedShotData, options TargetOptions) error {
+	if err := options.validateDistances(); err != nil {
+		return err
+	}
+	calculateTargets(shotData, options)
+	return nil
+}
+
+// validateDistances checks that every distance in the options is DistanceTotal or DistanceCarry
+func (options TargetOptions) validateDistances() error {
+	if !isDistance(options.Distance) {
+		return fmt.Errorf("unknown distance '%s'", options.Distance)
+	}
+	for shotType, distance := range options.TypeDistances {
+		if !isDistance(distance) {
+			return fmt.Errorf("unknown distance '%s' for type '%s'", distance, shotType)
+		}
+	}
+	for club, distance := range options.ClubDistances {
+		if !isDistance(distance) {
+			return fmt.Errorf("unknown distance '%s' for club '%s'", distance, club)
+		}
+	}
+	return nil
+}
+
+// isDistance reports whether a distance can be targeted on, with empty meaning total
+func isDistance(distance string) bool {
+	return distance == "" || distance == DistanceTotal || distance == DistanceCarry
+}
+
+// distance returns the distance a club's target is calculated from: the club's override,
+// its shot type's or the options' distance, or total when none is set
+func (options TargetOptions) distance(club, shotType string) string {
+	if distance := options.ClubDistances[club]; distance != "" {
+		return distance
+	}
+	if distance := options.TypeDistances[shotType]; distance != "" {
+		return distance
 	}
+	if options.Distance != "" {
+		return options.Distance
+	}
+	return DistanceTotal
+}
+
+// clubDistance returns the distance a club's shots are measured on: the distance the options
+// target the club on, or total for a club targeted on carry without any carry distances
+func (options TargetOptions) clubDistance(club string, shots []models.ProcessedShotData) string {
+	distance := options.distance(club, shots[0].Type)
+	if distance == DistanceCarry && len(options.targetDistances(shots, distance)) == 0 {
+		return DistanceTotal
+	}
+	return distance
+}
+
+// shotDistance reads the distance a target is calculated from, reporting false for shots that don't have it
+func shotDistance(shot models.ProcessedShotData, distance string) (float64, bool) {
+	if distance == DistanceCarry {
+		return shot.Carry, shot.Carry > 0
+	}
+	return shot.Total, true
+}
+
+// calculateTargets sets each shot's Target to its club's strategy applied to its club's distance
+// over the club's shots, and TargetDistance to the distance used. Shots without the distance are
+// left out, and a club targeted on carry without any carry distances is targeted on total instead.
+// Flagged shots are left out too unless options include them, as are outliers when options exclude
+// them, unless that would leave out every one of a club's shots, so each club still gets a target.
+func calculateTargets(shotData *[]models.ProcessedShotData, options TargetOptions) {
+	clubs, clubShots := groupShots(*shotData)
 
-	// Calculate median for each club type
-	for club, distances := range clubShots {
-		median := calculateMedian(distances)
+	// Calculate the target for each club type
+	targets := make(map[string]float64, len(clubs))
+	clubDistances := make(map[string]string, len(clubs))
+	for _, club := range clubs {
+		shots := clubShots[club]
+		distance := options.clubDistance(club, shots)
+		distances := options.targetDistances(shots, distance)
+		targets[club] = options.strategy(club).Target(distances)
+		clubDistances[club] = distance
+	}
 
-		// Update the Target field for all shots of this club type
-		for i := range *shotData {
-			if (*shotData)[i].Club == club {
-				(*shotData)[i].Target = median
-			}
+	// Update the Target fields for all shots of each club type
+	for i := range *shotData {
+		shot := &(*shotData)[i]
+		shot.Target = targets[shot.Club]
+		shot.TargetDistance = clubDistances[shot.Club]
+	}
+}
+
+// groupShots groups shots by club type, returning the clubs in the order they first appear
+func groupShots(shotData []models.ProcessedShotData) ([]string, map[string][]models.ProcessedShotData) {
+	var clubs []string
+	clubShots := make(map[string][]models.ProcessedShotData)
+	for _, shot := range shotData {
+		if _, seen := clubShots[shot.Club]; !seen {
+			clubs = append(clubs, shot.Club)
+		}
+		clubShots[shot.Club] = append(clubShots[shot.Club], shot)
+	}
+	return clubs, clubShots
+}
+
+// targetDistances returns the distances of a club's shots a target is calculated from, leaving
+// out flagged shots and outliers as the options say unless that would leave out every shot
+func (options TargetOptions) targetDistances(shots []models.ProcessedShotData, distance string) []float64 {
+	var included, all []float64
+	for _, shot := range shots {
+		value, ok := shotDistance(shot, distance)
+		if !ok {
+			continue
+		}
+		all = append(all, value)
+		if (len(shot.Flags) == 0 || options.IncludeFlagged) && (shot.Outlier == "" || !options.ExcludeOutliers) {
+			included = append(included, value)
 		}
 	}
+	if len(included) == 0 {
+		return all
+	}
+	return included
+}
+
+// strategy returns the target strategy for a club: its override, the options' strategy or the median
+func (options TargetOptions) strategy(club string) TargetStrategy {
+	if strategy, ok := options.ClubStrategies[club]; ok && strategy != nil {
+		return strategy
+	}
+	if options.Strategy != nil {
+		return options.Strategy
+	}
+	return Median{}
 }
 
 // calculateMedian computes the median value from a slice of float64 numbers.
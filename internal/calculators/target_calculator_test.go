@@ -21,9 +21,9 @@ func TestCalculateTargets(t *testing.T) {
 				{Club: "7i", Total: 155},
 			},
 			expected: []models.ProcessedShotData{
-				{Club: "7i", Total: 150, Target: 155},
-				{Club: "7i", Total: 160, Target: 155},
-				{Club: "7i", Total: 155, Target: 155},
+				{Club: "7i", Total: 150, Target: 155, TargetDistance: DistanceTotal},
+				{Club: "7i", Total: 160, Target: 155, TargetDistance: DistanceTotal},
+				{Club: "7i", Total: 155, Target: 155, TargetDistance: DistanceTotal},
 			},
 		},
 		{
@@ -38,13 +38,13 @@ func TestCalculateTargets(t *testing.T) {
 				{Club: "7i", Total: 155},
 			},
 			expected: []models.ProcessedShotData{
-				{Club: "Dr", Total: 250, Target: 255},
-				{Club: "Dr", Total: 260, Target: 255},
-				{Club: "3W", Total: 220, Target: 225},
-				{Club: "3W", Total: 230, Target: 225},
-				{Club: "7i", Total: 150, Target: 155},
-				{Club: "7i", Total: 160, Target: 155},
-				{Club: "7i", Total: 155, Target: 155},
+				{Club: "Dr", Total: 250, Target: 255, TargetDistance: DistanceTotal},
+				{Club: "Dr", Total: 260, Target: 255, TargetDistance: DistanceTotal},
+				{Club: "3W", Total: 220, Target: 225, TargetDistance: DistanceTotal},
+				{Club: "3W", Total: 230, Target: 225, TargetDistance: DistanceTotal},
+				{Club: "7i", Total: 150, Target: 155, TargetDistance: DistanceTotal},
+				{Club: "7i", Total: 160, Target: 155, TargetDistance: DistanceTotal},
+				{Club: "7i", Total: 155, Target: 155, TargetDistance: DistanceTotal},
 			},
 		},
 		{
@@ -56,10 +56,10 @@ func TestCalculateTargets(t *testing.T) {
 				{Club: "5i", Total: 195},
 			},
 			expected: []models.ProcessedShotData{
-				{Club: "5i", Total: 180, Target: 187.5},
-				{Club: "5i", Total: 185, Target: 187.5},
-				{Club: "5i", Total: 190, Target: 187.5},
-				{Club: "5i", Total: 195, Target: 187.5},
+				{Club: "5i", Total: 180, Target: 187.5, TargetDistance: DistanceTotal},
+				{Club: "5i", Total: 185, Target: 187.5, TargetDistance: DistanceTotal},
+				{Club: "5i", Total: 190, Target: 187.5, TargetDistance: DistanceTotal},
+				{Club: "5i", Total: 195, Target: 187.5, TargetDistance: DistanceTotal},
 			},
 		},
 		{
@@ -74,13 +74,13 @@ func TestCalculateTargets(t *testing.T) {
 				{Club: "Sw", Total: 105},
 			},
 			expected: []models.ProcessedShotData{
-				{Club: "4Hy", Total: 200, Target: 205},
-				{Club: "4Hy", Total: 210, Target: 205},
-				{Club: "4Hy", Total: 205, Target: 205},
-				{Club: "Pw", Total: 120, Target: 122.5},
-				{Club: "Pw", Total: 125, Target: 122.5},
-				{Club: "Sw", Total: 100, Target: 102.5},
-				{Club: "Sw", Total: 105, Target: 102.5},
+				{Club: "4Hy", Total: 200, Target: 205, TargetDistance: DistanceTotal},
+				{Club: "4Hy", Total: 210, Target: 205, TargetDistance: DistanceTotal},
+				{Club: "4Hy", Total: 205, Target: 205, TargetDistance: DistanceTotal},
+				{Club: "Pw", Total: 120, Target: 122.5, TargetDistance: DistanceTotal},
+				{Club: "Pw", Total: 125, Target: 122.5, TargetDistance: DistanceTotal},
+				{Club: "Sw", Total: 100, Target: 102.5, TargetDistance: DistanceTotal},
+				{Club: "Sw", Total: 105, Target: 102.5, TargetDistance: DistanceTotal},
 			},
 		},
 	}
@@ -117,3 +117,153 @@ func TestCalculateMedian(t *testing.T) {
 		})
 	}
 }
+
+func TestCalculateCarryTargets(t *testing.T) {
+	shotData := []models.ProcessedShotData{
+		{Club: "7i", Carry: 140, Total: 150},
+		{Club: "7i", Carry: 146, Total: 158},
+		{Club: "7i", Total: 155},
+		{Club: "Dr", Total: 250},
+	}
+	expected := []models.ProcessedShotData{
+		{Club: "7i", Carry: 140, Total: 150, Target: 143, TargetDistance: DistanceCarry},
+		{Club: "7i", Carry: 146, Total: 158, Target: 143, TargetDistance: DistanceCarry},
+		{Club: "7i", Total: 155, Target: 143, TargetDistance: DistanceCarry},
+		// The driver has no carry distances, so it falls back to total
+		{Club: "Dr", Total: 250, Target: 250, TargetDistance: DistanceTotal},
+	}
+
+	CalculateCarryTargets(&shotData)
+	if !reflect.DeepEqual(shotData, expected) {
+		t.Errorf("CalculateCarryTargets() = %v, want %v", shotData, expected)
+	}
+}
+
+func TestCalculateTargetsWithOptions(t *testing.T) {
+	misread := []string{"smash factor 1.70 above 1.55"}
+	newShotData := func() []models.ProcessedShotData {
+		return []models.ProcessedShotData{
+			{Club: "7i", Carry: 140, Total: 150},
+			{Club: "7i", Carry: 145, Total: 155},
+			{Club: "7i", Carry: 60, Total: 70, Flags: misread},
+			{Club: "Dr", Carry: 200, Total: 210, Flags: misread},
+			{Club: "Dr", Carry: 220, Total: 230, Flags: misread},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		options  TargetOptions
+		expected []float64
+	}{
+		// Every driver shot is flagged, so the driver's target falls back to all of them
+		{"Flagged shots left out", TargetOptions{}, []float64{152.5, 152.5, 152.5, 220, 220}},
+		{"Flagged shots included", TargetOptions{IncludeFlagged: true}, []float64{150, 150, 150, 220, 220}},
+		{"Carry", TargetOptions{Distance: DistanceCarry}, []float64{142.5, 142.5, 142.5, 210, 210}},
+		{"Strategy", TargetOptions{Strategy: Percentile{Percent: 0}}, []float64{150, 150, 150, 210, 210}},
+		{"Club strategy", TargetOptions{Strategy: Mean{}, ClubStrategies: map[string]TargetStrategy{"Dr": Percentile{Percent: 100}}}, []float64{152.5, 152.5, 152.5, 230, 230}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shotData := newShotData()
+			if err := CalculateTargetsWithOptions(&shotData, tt.options); err != nil {
+				t.Fatalf("CalculateTargetsWithOptions failed: %v", err)
+			}
+			for i, shot := range shotData {
+				if shot.Target != tt.expected[i] {
+					t.Errorf("Shot %d target = %v, want %v", i, shot.Target, tt.expected[i])
+				}
+			}
+		})
+	}
+
+	shotData := newShotData()
+	if err := CalculateTargetsWithOptions(&shotData, TargetOptions{Distance: "apex"}); err == nil {
+		t.Errorf("Expected error for unknown distance, got nil")
+	}
+}
+
+func TestCalculateTargetsExcludeOutliers(t *testing.T) {
+	shotData := toppedSevenIron()
+	DetectOutliers(shotData, IQRFences{K: 1.5}, TargetOptions{})
+
+	// The topped shot pulls the median of six shots down between the third and fourth longest
+	if err := CalculateTargetsWithOptions(&shotData, TargetOptions{}); err != nil {
+		t.Fatalf("CalculateTargetsWithOptions failed: %v", err)
+	}
+	if shotData[0].Target != 149.5 {
+		t.Errorf("Target with outliers = %v, want 149.5", shotData[0].Target)
+	}
+
+	if err := CalculateTargetsWithOptions(&shotData, TargetOptions{ExcludeOutliers: true}); err != nil {
+		t.Fatalf("CalculateTargetsWithOptions failed: %v", err)
+	}
+	if shotData[0].Target != 150 {
+		t.Errorf("Target without outliers = %v, want 150", shotData[0].Target)
+	}
+}
+
+func TestCalculateTargetsDistances(t *testing.T) {
+	newShotData := func() []models.ProcessedShotData {
+		return []models.ProcessedShotData{
+			{Club: "Dr", Type: "Tee", Carry: 230, Total: 250},
+			{Club: "7i", Type: "Approach", Carry: 150, Total: 160},
+			{Club: "Pw", Type: "Approach", Carry: 120, Total: 125},
+			{Club: "Sw", Type: "Approach", Total: 95},
+		}
+	}
+
+	tests := []struct {
+		name              string
+		options           TargetOptions
+		expectedTargets   []float64
+		expectedDistances []string
+	}{
+		{
+			name:              "Default",
+			options:           TargetOptions{},
+			expectedTargets:   []float64{250, 160, 125, 95},
+			expectedDistances: []string{DistanceTotal, DistanceTotal, DistanceTotal, DistanceTotal},
+		},
+		{
+			// The sand wedge has no carry distances, so it falls back to total
+			name:              "Approach on carry",
+			options:           TargetOptions{TypeDistances: map[string]string{"Approach": DistanceCarry}},
+			expectedTargets:   []float64{250, 150, 120, 95},
+			expectedDistances: []string{DistanceTotal, DistanceCarry, DistanceCarry, DistanceTotal},
+		},
+		{
+			name: "Club overrides type",
+			options: TargetOptions{
+				Distance:      DistanceCarry,
+				TypeDistances: map[string]string{"Approach": DistanceCarry},
+				ClubDistances: map[string]string{"Pw": DistanceTotal},
+			},
+			expectedTargets:   []float64{230, 150, 125, 95},
+			expectedDistances: []string{DistanceCarry, DistanceCarry, DistanceTotal, DistanceTotal},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shotData := newShotData()
+			if err := CalculateTargetsWithOptions(&shotData, tt.options); err != nil {
+				t.Fatalf("CalculateTargetsWithOptions failed: %v", err)
+			}
+			for i, shot := range shotData {
+				if shot.Target != tt.expectedTargets[i] || shot.TargetDistance != tt.expectedDistances[i] {
+					t.Errorf("Shot %d target = %v from %q, want %v from %q", i, shot.Target, shot.TargetDistance, tt.expectedTargets[i], tt.expectedDistances[i])
+				}
+			}
+		})
+	}
+
+	shotData := newShotData()
+	if err := CalculateTargetsWithOptions(&shotData, TargetOptions{TypeDistances: map[string]string{"Tee": "roll"}}); err == nil {
+		t.Errorf("Expected error for unknown type distance, got nil")
+	}
+	if err := CalculateTargetsWithOptions(&shotData, TargetOptions{ClubDistances: map[string]string{"Dr": "apex"}}); err == nil {
+		t.Errorf("Expected error for unknown club distance, got nil")
+	}
+}
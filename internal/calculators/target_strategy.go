@@ -0,0 +1,179 @@
+package calculators
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// TargetStrategy turns the distances of a club's shots into the club's target, its "number"
+type TargetStrategy interface {
+	// Target returns the target for a club's distances. It may reorder distances,
+	// which always holds at least one value.
+	Target(distances []float64) float64
+	// String returns the strategy in the form ParseTargetStrategy reads, e.g. "p75"
+	String() string
+}
+
+// TargetStrategies lists the target strategy names ParseTargetStrategy accepts
+var TargetStrategies = []string{"median", "mean", "trimmed-mean[:percent]", "p<percentile>", "kde-mode"}
+
+// defaultTrimPercent is the share of shots trimmed from each end by "trimmed-mean" without a percent
+const defaultTrimPercent = 10
+
+// ParseTargetStrategy reads a target strategy name, ignoring case:
+//   - "median": the middle distance (the default)
+//   - "mean": the average distance
+//   - "trimmed-mean" or "trimmed-mean:20": the average after dropping the given percent
+//     of shots (10 by default) from each end
+//   - "p75" or "percentile:75": the distance the given percent of shots fall short of
+//   - "kde-mode": the most likely distance, the peak of a kernel density estimate
+func ParseTargetStrategy(name string) (TargetStrategy, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	base, parameter, hasParameter := strings.Cut(name, ":")
+	if percent, ok := strings.CutPrefix(base, "p"); ok && !hasParameter {
+		if _, err := strconv.ParseFloat(percent, 64); err == nil {
+			base, parameter, hasParameter = "percentile", percent, true
+		}
+	}
+
+	switch base {
+	case "", "median":
+		if hasParameter {
+			break
+		}
+		return Median{}, nil
+	case "mean":
+		if hasParameter {
+			break
+		}
+		return Mean{}, nil
+	case "trimmed-mean":
+		percent := float64(defaultTrimPercent)
+		if hasParameter {
+			value, err := strconv.ParseFloat(parameter, 64)
+			if err != nil || !isFinite(value) || value < 0 || value >= 50 {
+				return nil, fmt.Errorf("invalid trim percent '%s' in target strategy '%s': must be at least 0 and below 50", parameter, name)
+			}
+			percent = value
+		}
+		return TrimmedMean{Percent: percent}, nil
+	case "percentile":
+		value, err := strconv.ParseFloat(parameter, 64)
+		if !hasParameter || err != nil || !isFinite(value) || value < 0 || value > 100 {
+			return nil, fmt.Errorf("invalid percentile '%s' in target strategy '%s': must be from 0 to 100", parameter, name)
+		}
+		return Percentile{Percent: value}, nil
+	case "kde-mode", "mode":
+		if hasParameter {
+			break
+		}
+		return KDEMode{}, nil
+	}
+	return nil, fmt.Errorf("unknown target strategy '%s'", name)
+}
+
+// isFinite reports whether a parsed parameter is a number, since strconv.ParseFloat also
+// reads "nan" and "inf", which no range check rejects
+func isFinite(value float64) bool {
+	return !math.IsNaN(value) && !math.IsInf(value, 0)
+}
+
+// Median targets the middle distance, so a few mishits don't move the target
+type Median struct{}
+
+func (Median) Target(distances []float64) float64 { return calculateMedian(distances) }
+func (Median) String() string                     { return "median" }
+
+// Mean targets the average distance
+type Mean struct{}
+
+func (Mean) Target(distances []float64) float64 { return calculateMean(distances) }
+func (Mean) String() string                     { return "mean" }
+
+// TrimmedMean targets the average distance after dropping Percent of the shots from each end,
+// which ignores mishits like the median while still using most of the shots
+type TrimmedMean struct {
+	Percent float64 // The percent of shots dropped from each end, below 50
+}
+
+func (strategy TrimmedMean) Target(distances []float64) float64 {
+	sort.Float64s(distances)
+	trim := int(float64(len(distances)) * strategy.Percent / 100)
+	return calculateMean(distances[trim : len(distances)-trim])
+}
+
+func (strategy TrimmedMean) String() string {
+	return "trimmed-mean:" + strconv.FormatFloat(strategy.Percent, 'f', -1, 64)
+}
+
+// Percentile targets the distance Percent of shots fall short of, interpolating between
+// shots. A high percentile such as P75 gives a "stock" number for a well struck shot and
+// a low one such as P25 a "worst reasonable" number for clearing hazards.
+type Percentile struct {
+	Percent float64 // From 0 (the shortest shot) to 100 (the longest)
+}
+
+func (strategy Percentile) Target(distances []float64) float64 {
+	sort.Float64s(distances)
+	rank := strategy.Percent / 100 * float64(len(distances)-1)
+	lower := int(math.Floor(rank))
+	if lower >= len(distances)-1 {
+		return distances[len(distances)-1]
+	}
+	return distances[lower] + (rank-float64(lower))*(distances[lower+1]-distances[lower])
+}
+
+func (strategy Percentile) String() string {
+	return "p" + strconv.FormatFloat(strategy.Percent, 'f', -1, 64)
+}
+
+// kdeSteps is the number of distances the density is evaluated at between the shortest and longest shot
+const kdeSteps = 500
+
+// KDEMode targets the most likely distance, the peak of a Gaussian kernel density estimate
+// of the distances, so a club's number follows where most shots cluster. The bandwidth
+// follows Silverman's rule of thumb.
+type KDEMode struct{}
+
+func (KDEMode) Target(distances []float64) float64 {
+	sort.Float64s(distances)
+	bandwidth := silvermanBandwidth(distances)
+	if bandwidth == 0 {
+		return calculateMedian(distances)
+	}
+
+	shortest, longest := distances[0], distances[len(distances)-1]
+	step := (longest - shortest) / kdeSteps
+	mode, peak := shortest, -1.0
+	for i := 0; i <= kdeSteps; i++ {
+		distance := shortest + float64(i)*step
+		density := 0.0
+		for _, value := range distances {
+			z := (distance - value) / bandwidth
+			density += math.Exp(-z * z / 2)
+		}
+		if density > peak {
+			mode, peak = distance, density
+		}
+	}
+	return math.Round(mode*100) / 100
+}
+
+func (KDEMode) String() string { return "kde-mode" }
+
+// silvermanBandwidth returns Silverman's rule of thumb bandwidth for sorted distances,
+// or 0 when they are too few or too alike to estimate a density from
+func silvermanBandwidth(sorted []float64) float64 {
+	if len(sorted) < 2 {
+		return 0
+	}
+	spread := calculateStdDev(sorted)
+	iqr := Percentile{Percent: 75}.Target(sorted) - Percentile{Percent: 25}.Target(sorted)
+	if iqr > 0 && iqr/1.34 < spread {
+		spread = iqr / 1.34
+	}
+	return 0.9 * spread * math.Pow(float64(len(sorted)), -0.2)
+}
@@ -0,0 +1,88 @@
+package calculators
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParseTargetStrategy(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected TargetStrategy
+		wantErr  bool
+	}{
+		{"", Median{}, false},
+		{"Median", Median{}, false},
+		{"mean", Mean{}, false},
+		{"trimmed-mean", TrimmedMean{Percent: 10}, false},
+		{"trimmed-mean:20", TrimmedMean{Percent: 20}, false},
+		{"p75", Percentile{Percent: 75}, false},
+		{"P25", Percentile{Percent: 25}, false},
+		{"percentile:90", Percentile{Percent: 90}, false},
+		{"kde-mode", KDEMode{}, false},
+		{"trimmed-mean:50", nil, true},
+		{"p150", nil, true},
+		{"percentile", nil, true},
+		{"mean:5", nil, true},
+		{"mode-ish", nil, true},
+		{"pnan", nil, true},
+		{"percentile:NaN", nil, true},
+		{"percentile:inf", nil, true},
+		{"trimmed-mean:nan", nil, true},
+		{"trimmed-mean:-inf", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			strategy, err := ParseTargetStrategy(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTargetStrategy(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if strategy != tt.expected {
+				t.Errorf("ParseTargetStrategy(%q) = %v, want %v", tt.name, strategy, tt.expected)
+			}
+			if strategy != nil {
+				if reparsed, err := ParseTargetStrategy(strategy.String()); err != nil || reparsed != strategy {
+					t.Errorf("ParseTargetStrategy(%q.String()) = %v, %v", tt.name, reparsed, err)
+				}
+			}
+		})
+	}
+}
+
+func TestTargetStrategies(t *testing.T) {
+	distances := []float64{150, 100, 152, 148, 151, 149, 170}
+
+	tests := []struct {
+		strategy TargetStrategy
+		expected float64
+	}{
+		{Median{}, 150},
+		{Mean{}, 145.71},
+		{TrimmedMean{Percent: 20}, 150},
+		{Percentile{Percent: 0}, 100},
+		{Percentile{Percent: 25}, 148.5},
+		{Percentile{Percent: 75}, 151.5},
+		{Percentile{Percent: 100}, 170},
+		{KDEMode{}, 149.98}, // The peak of the density, found to within a 500th of the spread
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.strategy.String(), func(t *testing.T) {
+			input := append([]float64(nil), distances...)
+			if result := tt.strategy.Target(input); math.Abs(result-tt.expected) > 0.01 {
+				t.Errorf("%v.Target() = %v, want %v", tt.strategy, result, tt.expected)
+			}
+		})
+	}
+
+	// A single shot, or shots that are all alike, are their own target
+	for _, strategy := range []TargetStrategy{Median{}, Mean{}, TrimmedMean{Percent: 10}, Percentile{Percent: 75}, KDEMode{}} {
+		if result := strategy.Target([]float64{142}); result != 142 {
+			t.Errorf("%v.Target([142]) = %v, want 142", strategy, result)
+		}
+		if result := strategy.Target([]float64{142, 142, 142}); result != 142 {
+			t.Errorf("%v.Target([142 142 142]) = %v, want 142", strategy, result)
+		}
+	}
+}
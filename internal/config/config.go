@@ -0,0 +1,163 @@
+// Package config reads the optional JSON configuration file given with -config,
+// which holds settings too detailed for command-line flags, such as per-club overrides.
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"albatross/internal/calculators"
+	"albatross/internal/processors"
+)
+
+// Config holds the settings read from a configuration file
+type Config struct {
+	TargetStrategy string                `json:"targetStrategy"` // The target strategy for every club, unless -target-strategy is given
+	Distance       string                `json:"distance"`       // The distance targets are calculated from, "total" or "carry", unless -distance is given
+	Types          map[string]TypeConfig `json:"types"`          // Settings for a type of shot, keyed by "Tee" or "Approach"
+	Clubs          map[string]ClubConfig `json:"clubs"`          // Settings for particular clubs, keyed by club name (e.g. "Driver" or "7i")
+}
+
+// TypeConfig holds the settings that can be overridden for a type of shot
+type TypeConfig struct {
+	Distance string `json:"distance"` // The distance targets of clubs of the type are calculated from, overriding the session's
+}
+
+// ClubConfig holds the settings that can be overridden for a single club
+type ClubConfig struct {
+	TargetStrategy string `json:"targetStrategy"` // The target strategy for the club, overriding the session's
+	Distance       string `json:"distance"`       // The distance the club's target is calculated from, overriding its type's
+}
+
+// shotTypes lists the shot types clubs are classified as, which Types can be keyed by
+var shotTypes = []string{"Tee", "Approach"}
+
+// Load reads and validates a JSON configuration file
+func Load(path string) (Config, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, fmt.Errorf("reading config '%s': %w", path, err)
+	}
+
+	var config Config
+	if err := json.Unmarshal(content, &config); err != nil {
+		return Config{}, fmt.Errorf("decoding config '%s': %w", path, err)
+	}
+
+	if err := config.Validate(); err != nil {
+		return Config{}, fmt.Errorf("invalid config '%s': %w", path, err)
+	}
+	return config, nil
+}
+
+// Validate checks that every target strategy can be read, that every distance is
+// "total" or "carry", that types are known and that no two club names refer to the same club
+func (config Config) Validate() error {
+	if config.TargetStrategy != "" {
+		if _, err := calculators.ParseTargetStrategy(config.TargetStrategy); err != nil {
+			return err
+		}
+	}
+	if err := validateDistance(config.Distance); err != nil {
+		return err
+	}
+
+	types := make(map[string]string)
+	for name, shotType := range config.Types {
+		normalized, ok := normalizeShotType(name)
+		if !ok {
+			return fmt.Errorf("unknown shot type '%s': must be one of %s", name, strings.Join(shotTypes, ", "))
+		}
+		if other, ok := types[normalized]; ok {
+			return fmt.Errorf("types '%s' and '%s' are both %s", other, name, normalized)
+		}
+		types[normalized] = name
+
+		if err := validateDistance(shotType.Distance); err != nil {
+			return fmt.Errorf("type '%s': %w", name, err)
+		}
+	}
+
+	clubs := make(map[string]string)
+	for name, club := range config.Clubs {
+		if strings.TrimSpace(name) == "" {
+			return fmt.Errorf("empty club name")
+		}
+		normalized := processors.NormalizeClubType(name)
+		if other, ok := clubs[normalized]; ok {
+			return fmt.Errorf("clubs '%s' and '%s' are both %s", other, name, normalized)
+		}
+		clubs[normalized] = name
+
+		if club.TargetStrategy != "" {
+			if _, err := calculators.ParseTargetStrategy(club.TargetStrategy); err != nil {
+				return fmt.Errorf("club '%s': %w", name, err)
+			}
+		}
+		if err := validateDistance(club.Distance); err != nil {
+			return fmt.Errorf("club '%s': %w", name, err)
+		}
+	}
+	return nil
+}
+
+// validateDistance checks that a distance can be targeted on, with empty meaning it isn't set
+func validateDistance(distance string) error {
+	switch distance {
+	case "", calculators.DistanceTotal, calculators.DistanceCarry:
+		return nil
+	}
+	return fmt.Errorf("unknown distance '%s': must be %s or %s", distance, calculators.DistanceTotal, calculators.DistanceCarry)
+}
+
+// normalizeShotType returns the shot type a Types key refers to, ignoring case
+func normalizeShotType(name string) (string, bool) {
+	for _, shotType := range shotTypes {
+		if strings.EqualFold(strings.TrimSpace(name), shotType) {
+			return shotType, true
+		}
+	}
+	return "", false
+}
+
+// ClubTargetStrategies returns the target strategy overrides keyed by normalized club type,
+// as calculators.TargetOptions expects them
+func (config Config) ClubTargetStrategies() (map[string]calculators.TargetStrategy, error) {
+	strategies := make(map[string]calculators.TargetStrategy)
+	for name, club := range config.Clubs {
+		if club.TargetStrategy == "" {
+			continue
+		}
+		strategy, err := calculators.ParseTargetStrategy(club.TargetStrategy)
+		if err != nil {
+			return nil, fmt.Errorf("club '%s': %w", name, err)
+		}
+		strategies[processors.NormalizeClubType(name)] = strategy
+	}
+	return strategies, nil
+}
+
+// TypeDistances returns the distance overrides keyed by shot type, as calculators.TargetOptions expects them
+func (config Config) TypeDistances() map[string]string {
+	distances := make(map[string]string)
+	for name, shotType := range config.Types {
+		if normalized, ok := normalizeShotType(name); ok && shotType.Distance != "" {
+			distances[normalized] = shotType.Distance
+		}
+	}
+	return distances
+}
+
+// ClubDistances returns the distance overrides keyed by normalized club type,
+// as calculators.TargetOptions expects them
+func (config Config) ClubDistances() map[string]string {
+	distances := make(map[string]string)
+	for name, club := range config.Clubs {
+		if club.Distance != "" {
+			distances[processors.NormalizeClubType(name)] = club.Distance
+		}
+	}
+	return distances
+}
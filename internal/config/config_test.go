@@ -0,0 +1,80 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"albatross/internal/calculators"
+)
+
+func TestLoad(t *testing.T) {
+	config, err := Load("../../examples/config/albatross.json")
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if config.TargetStrategy != "median" || config.Clubs["Driver"].TargetStrategy != "p25" {
+		t.Errorf("Unexpected config: %+v", config)
+	}
+
+	strategies, err := config.ClubTargetStrategies()
+	if err != nil {
+		t.Fatalf("ClubTargetStrategies failed: %v", err)
+	}
+	expected := map[string]calculators.TargetStrategy{
+		"Dr": calculators.Percentile{Percent: 25},
+		"Pw": calculators.KDEMode{},
+	}
+	if !reflect.DeepEqual(strategies, expected) {
+		t.Errorf("ClubTargetStrategies() = %v, want %v", strategies, expected)
+	}
+
+	if distances := config.TypeDistances(); !reflect.DeepEqual(distances, map[string]string{"Approach": "carry"}) {
+		t.Errorf("TypeDistances() = %v", distances)
+	}
+	if distances := config.ClubDistances(); !reflect.DeepEqual(distances, map[string]string{"4Hy": "total"}) {
+		t.Errorf("ClubDistances() = %v", distances)
+	}
+
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Errorf("Expected error for a missing file, got nil")
+	}
+
+	invalid := filepath.Join(t.TempDir(), "invalid.json")
+	if err := os.WriteFile(invalid, []byte(`{"targetStrategy": `), 0o644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	if _, err := Load(invalid); err == nil {
+		t.Errorf("Expected error for malformed JSON, got nil")
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr bool
+	}{
+		{"Empty", Config{}, false},
+		{"Valid", Config{TargetStrategy: "trimmed-mean:15", Clubs: map[string]ClubConfig{"7 Iron": {TargetStrategy: "p75"}}}, false},
+		{"Unknown strategy", Config{TargetStrategy: "average"}, true},
+		{"Unknown club strategy", Config{Clubs: map[string]ClubConfig{"Driver": {TargetStrategy: "p200"}}}, true},
+		{"Same club twice", Config{Clubs: map[string]ClubConfig{"Driver": {}, "Dr": {}}}, true},
+		{"Empty club name", Config{Clubs: map[string]ClubConfig{" ": {}}}, true},
+		{"Distances", Config{Distance: "carry", Types: map[string]TypeConfig{"tee": {Distance: "total"}}, Clubs: map[string]ClubConfig{"Driver": {Distance: "carry"}}}, false},
+		{"Unknown distance", Config{Distance: "roll"}, true},
+		{"Unknown type", Config{Types: map[string]TypeConfig{"Putt": {Distance: "total"}}}, true},
+		{"Unknown type distance", Config{Types: map[string]TypeConfig{"Approach": {Distance: "apex"}}}, true},
+		{"Same type twice", Config{Types: map[string]TypeConfig{"Tee": {}, "tee": {}}}, true},
+		{"Unknown club distance", Config{Clubs: map[string]ClubConfig{"7i": {Distance: "side"}}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
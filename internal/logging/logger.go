@@ -31,6 +31,15 @@ func Info(message string, fields Fields) {
 	event.Msg(message)
 }
 
+// Warn logs a warning level message with optional fields
+func Warn(message string, fields Fields) {
+	event := log.Warn()
+	for k, v := range fields {
+		event = event.Interface(k, v)
+	}
+	event.Msg(message)
+}
+
 // Error logs an error level message with optional fields
 func Error(message string, err error, fields Fields) {
 	event := log.Error().Err(err)
@@ -1,11 +1,55 @@
 package models
 
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
 // LaunchMonitor interface defines the methods that any launch monitor type should implement
 type LaunchMonitor interface {
 	// ParseRow converts a row of strings into a RawShotData struct
 	ParseRow(row []string, headers []string) (RawShotData, error)
-	// ProcessRawData converts RawShotData into ProcessedShotData
-	ProcessRawData(rawData RawShotData) ProcessedShotData
+	// ProcessRawData converts RawShotData into ProcessedShotData.
+	// It returns an error, usually a *FieldError, if a value the shot needs cannot be read.
+	ProcessRawData(rawData RawShotData) (ProcessedShotData, error)
+}
+
+// FieldError reports a value in a row or shot that could not be read
+type FieldError struct {
+	Field  string // The column or field the value was read from
+	Value  string // The raw value
+	Reason string // Why the value could not be read
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("column '%s': %s", e.Field, e.Reason)
+}
+
+// DocumentParser is implemented by launch monitors whose exports are structured
+// documents (e.g. JSON) rather than rows of a CSV file
+type DocumentParser interface {
+	// ParseDocument reads a complete export and returns the raw data for each shot it contains
+	ParseDocument(r io.Reader) ([]RawShotData, error)
+}
+
+// SessionParser is implemented by launch monitors whose exports describe the session
+// in title rows above the shot data (e.g. "Rapsodo MLM2PRO: Palmer Little - 09/05/2024 9:27 PM")
+type SessionParser interface {
+	// ParseSessionRow reads session details from a row above the first header row into session.
+	// It reports whether the row described the session.
+	ParseSessionRow(row []string, session *Session) bool
+}
+
+// Session describes where a set of shots came from, so sessions can be told apart once merged
+type Session struct {
+	Device            string     `json:"device,omitempty"`            // The launch monitor as named in the export (e.g. "Rapsodo MLM2PRO")
+	Player            string     `json:"player,omitempty"`            // The player's name as entered in the launch monitor app
+	StartTime         *time.Time `json:"startTime,omitempty"`         // When the session started, in the export's local time
+	Source            string     `json:"source,omitempty"`            // The input file the shots were read from
+	LaunchMonitorType string     `json:"launchMonitorType,omitempty"` // The launch monitor type the shots were read as
+	Units             string     `json:"units,omitempty"`             // The unit system of the processed shot data
+	InputUnits        string     `json:"inputUnits,omitempty"`        // The unit system the export was read in: "imperial" or "metric", "mixed" when its headers give both, or empty when the reader converts units itself
 }
 
 // RawShotData represents the raw data from any launch monitor
@@ -14,14 +58,117 @@ type RawShotData struct {
 	Data              map[string]string
 }
 
-// ProcessedShotData represents the standardized processed shot data
+// ProcessedShotData represents the standardized processed shot data. Readers produce
+// distances in yards, apex heights in feet and speeds in mph, which can be converted for
+// output with processors.ConvertUnits.
 type ProcessedShotData struct {
-	Apex   float64 // The highest point of the shot's trajectory
-	Carry  float64 // The carry distance of the shot
-	Club   string  // The type of club used for the shot
-	Roll   string  // The difference between total and carry distance
-	Type   string  // The type of shot (e.g., "Tee" or "Approach")
-	Target float64 // The target distance for this club type
-	Total  float64 // The total distance of the shot
-	Side   float64 // The side carry (lateral deviation) of the shot
+	Apex   float64 `json:"apex,omitempty"`  // The height of the highest point of the shot's trajectory, in feet
+	Carry  float64 `json:"carry,omitempty"` // The carry distance of the shot
+	Club   string  `json:"club"`            // The type of club used for the shot
+	Roll   float64 `json:"roll,omitempty"`  // The difference between total and carry distance
+	Type   string  `json:"type"`            // The type of shot (e.g., "Tee" or "Approach")
+	Target float64 `json:"target"`          // The target distance for this club type
+	Total  float64 `json:"total"`           // The total distance of the shot
+	Side   float64 `json:"side"`            // The side carry (lateral deviation) of the shot
+
+	// Ball launch data, reported by most launch monitors. Angles are in degrees and spin in rpm.
+	BallSpeed       float64 `json:"ballSpeed,omitempty"`       // The speed of the ball off the face
+	LaunchAngle     float64 `json:"launchAngle,omitempty"`     // The vertical launch angle of the ball
+	LaunchDirection float64 `json:"launchDirection,omitempty"` // The horizontal launch angle relative to the target line, negative is to the left
+	DescentAngle    float64 `json:"descentAngle,omitempty"`    // The angle at which the ball lands
+	SpinRate        float64 `json:"spinRate,omitempty"`        // The total spin rate of the ball
+	SpinAxis        float64 `json:"spinAxis,omitempty"`        // The tilt of the spin axis, negative is to the left (draw spin for a right-handed golfer)
+	ClubSpeed       float64 `json:"clubSpeed,omitempty"`       // The speed of the club head at impact
+	SmashFactor     float64 `json:"smashFactor,omitempty"`     // Ball speed divided by club speed
+	ClubBrand       string  `json:"clubBrand,omitempty"`       // The manufacturer of the club, as entered in the launch monitor app
+	ClubModel       string  `json:"clubModel,omitempty"`       // The model of the club, as entered in the launch monitor app
+
+	// Club delivery data, reported by launch monitors that measure the club (e.g. Foresight GCQuad)
+	ClubPath    float64 `json:"clubPath,omitempty"`    // The horizontal path of the club through impact in degrees, negative is to the left
+	FaceAngle   float64 `json:"faceAngle,omitempty"`   // The face angle relative to the target line at impact in degrees, negative is to the left
+	AttackAngle float64 `json:"attackAngle,omitempty"` // The vertical angle of the club's path at impact in degrees, negative is descending
+	DynamicLoft float64 `json:"dynamicLoft,omitempty"` // The loft presented at impact in degrees
+
+	Flags   []string `json:"flags,omitempty"`   // Why the shot looks like a launch monitor misread; flagged shots are left out of targets by default
+	Outlier string   `json:"outlier,omitempty"` // Why the shot is a statistical outlier among the club's shots, when outliers are detected
+
+	TargetDistance string `json:"targetDistance,omitempty"` // The distance Target was calculated from, "total" or "carry"
+}
+
+// FlagSeparator joins a shot's flags where they are written as text, such as the Flag column of ShotPattern CSV
+const FlagSeparator = "; "
+
+// ClubSummary aggregates the processed shots hit with a single club
+type ClubSummary struct {
+	Club           string  `json:"club"`                     // The normalized club type
+	Type           string  `json:"type"`                     // The type of shot (e.g., "Tee" or "Approach")
+	Shots          int     `json:"shots"`                    // The number of shots hit with the club
+	Flagged        int     `json:"flagged,omitempty"`        // The number of those shots flagged as likely misreads
+	Outliers       int     `json:"outliers,omitempty"`       // The number of those shots that are statistical outliers
+	Target         float64 `json:"target"`                   // The target distance for the club
+	TargetDistance string  `json:"targetDistance,omitempty"` // The distance the target was calculated from, "total" or "carry"
+	MeanTotal      float64 `json:"meanTotal"`                // The mean total distance
+	MedianTotal    float64 `json:"medianTotal"`              // The median total distance
+	MeanCarry      float64 `json:"meanCarry,omitempty"`      // The mean carry distance, over shots with a carry distance
+	MedianCarry    float64 `json:"medianCarry,omitempty"`    // The median carry distance, over shots with a carry distance
+	MeanSide       float64 `json:"meanSide"`                 // The mean side carry
+	SideStdDev     float64 `json:"sideStdDev"`               // The sample standard deviation of side carry
+
+	// Mean launch data, over shots that report each measurement
+	MeanBallSpeed   float64 `json:"meanBallSpeed,omitempty"`   // The mean ball speed
+	MeanClubSpeed   float64 `json:"meanClubSpeed,omitempty"`   // The mean club speed
+	MeanSmashFactor float64 `json:"meanSmashFactor,omitempty"` // The mean smash factor
+	MeanLaunchAngle float64 `json:"meanLaunchAngle,omitempty"` // The mean vertical launch angle in degrees
+	MeanSpinRate    float64 `json:"meanSpinRate,omitempty"`    // The mean spin rate in rpm
+}
+
+// ClubDispersion describes how a club's shots spread over total distance and side carry,
+// the shape the ShotPattern app draws for each club
+type ClubDispersion struct {
+	Club          string              `json:"club"`               // The normalized club type
+	Shots         int                 `json:"shots"`              // The number of shots the dispersion is calculated from
+	MeanTotal     float64             `json:"meanTotal"`          // The mean total distance
+	MeanSide      float64             `json:"meanSide"`           // The mean side carry
+	TotalVariance float64             `json:"totalVariance"`      // The sample variance of total distance
+	SideVariance  float64             `json:"sideVariance"`       // The sample variance of side carry
+	Covariance    float64             `json:"covariance"`         // The sample covariance of total distance and side carry
+	Ellipses      []DispersionEllipse `json:"ellipses,omitempty"` // The 1σ and 2σ ellipses, when there are enough shots to draw them
+}
+
+// DispersionEllipse is an ellipse around a club's mean shot that extends Sigma standard
+// deviations along each of its axes
+type DispersionEllipse struct {
+	Sigma       float64 `json:"sigma"`       // The number of standard deviations the ellipse extends
+	CenterTotal float64 `json:"centerTotal"` // The total distance of the ellipse's center, the mean total distance
+	CenterSide  float64 `json:"centerSide"`  // The side carry of the ellipse's center, the mean side carry
+	SemiMajor   float64 `json:"semiMajor"`   // Half the length of the ellipse's longest axis
+	SemiMinor   float64 `json:"semiMinor"`   // Half the length of the ellipse's shortest axis
+	Angle       float64 `json:"angle"`       // The angle of the longest axis from the target line in degrees, from -90 to 90, positive when longer shots go right
+}
+
+// Gapping describes how evenly a bag's clubs cover the distances between them
+type Gapping struct {
+	Distance string        `json:"distance"` // The distance gaps and overlaps are judged on, "total" or "carry"
+	Clubs    []GappingClub `json:"clubs"`    // The clubs from the least lofted to the most
+	Gaps     []ClubGap     `json:"gaps"`     // The gaps between consecutive clubs
+}
+
+// GappingClub is a club's distances in a bag's gapping
+type GappingClub struct {
+	Club  string  `json:"club"`            // The normalized club type
+	Loft  float64 `json:"loft,omitempty"`  // The club type's typical loft in degrees, when it is known
+	Shots int     `json:"shots"`           // The number of shots the distances are calculated from
+	Carry float64 `json:"carry,omitempty"` // The club's carry target, when the launch monitor reports carry
+	Total float64 `json:"total"`           // The club's total target
+}
+
+// ClubGap is the gap between a club and the next more lofted club
+type ClubGap struct {
+	Longer      string  `json:"longer"`                // The less lofted club
+	Shorter     string  `json:"shorter"`               // The more lofted club
+	CarryGap    float64 `json:"carryGap,omitempty"`    // The difference between the clubs' carry targets, when both report carry
+	TotalGap    float64 `json:"totalGap"`              // The difference between the clubs' total targets
+	Overlap     float64 `json:"overlap"`               // The chance a shot with the shorter club goes at least as far as one with the longer club
+	Hole        bool    `json:"hole,omitempty"`        // Whether the gap is too large
+	Overlapping bool    `json:"overlapping,omitempty"` // Whether the clubs' distances overlap too much
 }
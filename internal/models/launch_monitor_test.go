@@ -18,13 +18,13 @@ func (m MockLaunchMonitor) ParseRow(row []string, headers []string) (RawShotData
 	}, nil
 }
 
-func (m MockLaunchMonitor) ProcessRawData(rawData RawShotData) ProcessedShotData {
+func (m MockLaunchMonitor) ProcessRawData(rawData RawShotData) (ProcessedShotData, error) {
 	return ProcessedShotData{
 		Club:  rawData.Data["club type"],
 		Type:  "Test",
 		Total: 0, // For simplicity, we're not converting strings to floats in this mock
 		Side:  0,
-	}
+	}, nil
 }
 
 func TestLaunchMonitorInterface(t *testing.T) {
@@ -43,7 +43,10 @@ func TestLaunchMonitorInterface(t *testing.T) {
 		t.Errorf("Expected LaunchMonitorType to be 'Mock', got %s", rawData.LaunchMonitorType)
 	}
 
-	processedData := mock.ProcessRawData(rawData)
+	processedData, err := mock.ProcessRawData(rawData)
+	if err != nil {
+		t.Errorf("ProcessRawData returned unexpected error: %v", err)
+	}
 	if processedData.Club != "Driver" {
 		t.Errorf("Expected Club to be 'Driver', got %s", processedData.Club)
 	}
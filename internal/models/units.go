@@ -0,0 +1,97 @@
+package models
+
+import "strings"
+
+// Unit systems processed shot data can be written in
+const (
+	UnitsImperial = "imperial" // Distances in yards and speeds in mph, as readers produce them
+	UnitsMetric   = "metric"   // Distances in meters and speeds in km/h
+)
+
+// UnitSystems lists the unit systems processed shot data can be written in
+var UnitSystems = []string{UnitsImperial, UnitsMetric}
+
+// DistanceUnit is a unit launch monitors export distances in
+type DistanceUnit string
+
+// Distance units
+const (
+	Yards  DistanceUnit = "yards"
+	Meters DistanceUnit = "meters"
+	Feet   DistanceUnit = "feet"
+)
+
+// SpeedUnit is a unit launch monitors export speeds in
+type SpeedUnit string
+
+// Speed units
+const (
+	MilesPerHour      SpeedUnit = "mph"
+	KilometersPerHour SpeedUnit = "km/h"
+	MetersPerSecond   SpeedUnit = "m/s"
+)
+
+// yardsPer and mphPer give the size of each unit in yards and mph
+var (
+	yardsPer = map[DistanceUnit]float64{Yards: 1, Meters: 1.0936133, Feet: 1.0 / 3.0}
+	mphPer   = map[SpeedUnit]float64{MilesPerHour: 1, KilometersPerHour: 0.62137119, MetersPerSecond: 2.2369363}
+)
+
+// distanceUnitLabels and speedUnitLabels map the lowercased labels exports use for units,
+// such as the "m" in a "Carry (m)" header, to the unit
+var (
+	distanceUnitLabels = map[string]DistanceUnit{
+		"yards": Yards, "yard": Yards, "yds": Yards, "yd": Yards,
+		"meters": Meters, "meter": Meters, "metres": Meters, "metre": Meters, "m": Meters,
+		"feet": Feet, "foot": Feet, "ft": Feet,
+	}
+	speedUnitLabels = map[string]SpeedUnit{
+		"mph":  MilesPerHour,
+		"km/h": KilometersPerHour, "kmh": KilometersPerHour, "kph": KilometersPerHour, "kmph": KilometersPerHour,
+		"m/s": MetersPerSecond, "mps": MetersPerSecond,
+	}
+)
+
+// ParseDistanceUnit returns the distance unit for a label such as "m" or "yards", ignoring case
+func ParseDistanceUnit(label string) (DistanceUnit, bool) {
+	unit, ok := distanceUnitLabels[strings.ToLower(strings.TrimSpace(label))]
+	return unit, ok
+}
+
+// ParseSpeedUnit returns the speed unit for a label such as "km/h" or "mph", ignoring case
+func ParseSpeedUnit(label string) (SpeedUnit, bool) {
+	unit, ok := speedUnitLabels[strings.ToLower(strings.TrimSpace(label))]
+	return unit, ok
+}
+
+// SystemUnits returns the distance and speed units of a unit system
+func SystemUnits(system string) (DistanceUnit, SpeedUnit, bool) {
+	switch strings.ToLower(system) {
+	case UnitsImperial:
+		return Yards, MilesPerHour, true
+	case UnitsMetric:
+		return Meters, KilometersPerHour, true
+	default:
+		return "", "", false
+	}
+}
+
+// ToYards converts a distance in the unit into yards
+func (unit DistanceUnit) ToYards(distance float64) float64 {
+	return distance * yardsPer[unit]
+}
+
+// FromYards converts a distance in yards into the unit
+func (unit DistanceUnit) FromYards(distance float64) float64 {
+	return distance / yardsPer[unit]
+}
+
+// ToMph converts a speed in the unit into mph
+func (unit SpeedUnit) ToMph(speed float64) float64 {
+	return speed * mphPer[unit]
+}
+
+// FromMph converts a speed in mph into the unit
+func (unit SpeedUnit) FromMph(speed float64) float64 {
+	return speed / mphPer[unit]
+}
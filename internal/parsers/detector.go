@@ -0,0 +1,223 @@
+package parsers
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"albatross/internal/reader"
+	"albatross/internal/xlsx"
+)
+
+const (
+	// detectionRowLimit is the number of CSV rows sniffed when detecting a launch monitor type
+	detectionRowLimit = 20
+	// minimumConfidence is the lowest confidence accepted for a detected launch monitor type
+	minimumConfidence = 0.5
+	// ambiguityMargin is how far the best candidate must lead the runner-up to be chosen
+	ambiguityMargin = 0.2
+)
+
+// Detection is a candidate launch monitor type for an input file and how confident we are in it
+type Detection struct {
+	LaunchMonitorType string
+	Confidence        float64 // Between 0 and 1
+}
+
+// AmbiguousDetectionError is returned when no launch monitor type can be chosen with confidence.
+// It lists the candidates that were considered, best first.
+type AmbiguousDetectionError struct {
+	Candidates []Detection
+}
+
+func (e *AmbiguousDetectionError) Error() string {
+	if len(e.Candidates) == 0 {
+		return "could not detect launch monitor type: no candidates matched"
+	}
+	candidates := make([]string, len(e.Candidates))
+	for i, candidate := range e.Candidates {
+		candidates[i] = fmt.Sprintf("%s (%.2f)", candidate.LaunchMonitorType, candidate.Confidence)
+	}
+	return fmt.Sprintf("could not detect launch monitor type, candidates: %s", strings.Join(candidates, ", "))
+}
+
+// DetectLaunchMonitorType sniffs the start of an input file using the default options.
+// See DetectLaunchMonitorTypeWithOptions.
+func DetectLaunchMonitorType(inputFile string) (Detection, error) {
+	return DetectLaunchMonitorTypeWithOptions(inputFile, Options{})
+}
+
+// DetectLaunchMonitorTypeWithOptions sniffs the start of an input file and returns the launch
+// monitor type whose export it most resembles. It returns an *AmbiguousDetectionError when no
+// candidate is confident enough or two candidates are too close to call.
+func DetectLaunchMonitorTypeWithOptions(inputFile string, options Options) (Detection, error) {
+	var candidates []Detection
+	if isWorkbook(inputFile) {
+		workbookRows, err := xlsx.NewReader(inputFile, options.Sheet)
+		if err != nil {
+			return Detection{}, err
+		}
+		rows, err := readLeadingRows(workbookRows, detectionRowLimit)
+		if err != nil {
+			return Detection{}, err
+		}
+		candidates = rankSignatures(func(signature reader.Signature) float64 { return scoreRows(signature, rows) })
+	} else {
+		file, err := os.Open(inputFile)
+		if err != nil {
+			return Detection{}, fmt.Errorf("opening file '%s': %w", inputFile, err)
+		}
+		defer file.Close()
+
+		candidates, err = scoreSignatures(file)
+		if err != nil {
+			return Detection{}, err
+		}
+	}
+	return chooseDetection(candidates)
+}
+
+// scoreSignatures scores the signature of every registered launch monitor against
+// a CSV or JSON input, best first, leaving out launch monitors that did not match at all.
+func scoreSignatures(input io.Reader) ([]Detection, error) {
+	bufferedInput := bufio.NewReader(input)
+
+	var scorer func(reader.Signature) float64
+	if isDocument(bufferedInput) {
+		keys, err := readDocumentKeys(bufferedInput)
+		if err != nil {
+			return nil, err
+		}
+		scorer = func(signature reader.Signature) float64 { return scoreDocument(signature, keys) }
+	} else {
+		rows, err := readLeadingRows(newCSVReader(bufferedInput), detectionRowLimit)
+		if err != nil {
+			return nil, err
+		}
+		scorer = func(signature reader.Signature) float64 { return scoreRows(signature, rows) }
+	}
+
+	return rankSignatures(scorer), nil
+}
+
+// rankSignatures scores the signature of every registered launch monitor, best first,
+// leaving out launch monitors that did not match at all.
+func rankSignatures(scorer func(reader.Signature) float64) []Detection {
+	var candidates []Detection
+	for _, registration := range reader.Registrations() {
+		if confidence := scorer(registration.Signature); confidence > 0 {
+			candidates = append(candidates, Detection{LaunchMonitorType: registration.Name, Confidence: confidence})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Confidence > candidates[j].Confidence
+	})
+	return candidates
+}
+
+// chooseDetection picks the best candidate if it is confident and clearly ahead of the runner-up.
+func chooseDetection(candidates []Detection) (Detection, error) {
+	if len(candidates) == 0 || candidates[0].Confidence < minimumConfidence {
+		return Detection{}, &AmbiguousDetectionError{Candidates: candidates}
+	}
+	if len(candidates) > 1 && candidates[0].Confidence-candidates[1].Confidence < ambiguityMargin {
+		return Detection{}, &AmbiguousDetectionError{Candidates: candidates}
+	}
+	return candidates[0], nil
+}
+
+// isDocument reports whether the input looks like a JSON document rather than CSV.
+func isDocument(input *bufio.Reader) bool {
+	peeked, _ := input.Peek(512)
+	trimmed := bytes.TrimLeft(peeked, " \t\r\n\ufeff")
+	return len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[')
+}
+
+// readDocumentKeys decodes a JSON document and returns its lowercased top-level keys.
+// For an array the keys of its first object are used.
+func readDocumentKeys(input io.Reader) (map[string]bool, error) {
+	var document json.RawMessage
+	if err := json.NewDecoder(input).Decode(&document); err != nil {
+		return nil, fmt.Errorf("decoding document: %w", err)
+	}
+
+	var items []json.RawMessage
+	if err := json.Unmarshal(document, &items); err == nil {
+		if len(items) == 0 {
+			return map[string]bool{}, nil
+		}
+		document = items[0]
+	}
+
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(document, &object); err != nil {
+		return map[string]bool{}, nil
+	}
+
+	keys := make(map[string]bool, len(object))
+	for key := range object {
+		keys[strings.ToLower(key)] = true
+	}
+	return keys, nil
+}
+
+// readLeadingRows reads up to limit rows from the input.
+func readLeadingRows(input rowReader, limit int) ([][]string, error) {
+	var rows [][]string
+	for len(rows) < limit {
+		row, err := input.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading row %d: %w", len(rows)+1, err)
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// scoreRows returns 1 if a row carries the signature's title, otherwise the largest
+// share of the signature's columns found in any single row.
+func scoreRows(signature reader.Signature, rows [][]string) float64 {
+	if len(signature.Columns) == 0 {
+		return 0
+	}
+
+	best := 0.0
+	for _, row := range rows {
+		if len(row) == 0 {
+			continue
+		}
+		if signature.Title != nil && signature.Title.MatchString(strings.TrimSpace(row[0])) {
+			return 1
+		}
+
+		matched := signature.MatchColumns(normalizeHeaders(row))
+		if score := float64(matched) / float64(len(signature.Columns)); score > best {
+			best = score
+		}
+	}
+	return best
+}
+
+// scoreDocument returns the share of the signature's document keys found in the document.
+func scoreDocument(signature reader.Signature, keys map[string]bool) float64 {
+	if len(signature.DocumentKeys) == 0 {
+		return 0
+	}
+
+	matched := 0
+	for _, key := range signature.DocumentKeys {
+		if keys[key] {
+			matched++
+		}
+	}
+	return float64(matched) / float64(len(signature.DocumentKeys))
+}
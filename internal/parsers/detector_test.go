@@ -0,0 +1,125 @@
+package parsers
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDetectLaunchMonitorType(t *testing.T) {
+	detection, err := DetectLaunchMonitorType("../../examples/input/mlm2pro.csv")
+	if err != nil {
+		t.Fatalf("DetectLaunchMonitorType failed: %v", err)
+	}
+	if detection.LaunchMonitorType != "mlm2pro" || detection.Confidence != 1 {
+		t.Errorf("DetectLaunchMonitorType() = %+v, want mlm2pro with confidence 1", detection)
+	}
+
+	detection, err = DetectLaunchMonitorType("../../examples/input/mlm2pro.xlsx")
+	if err != nil {
+		t.Fatalf("DetectLaunchMonitorType failed for workbook: %v", err)
+	}
+	if detection.LaunchMonitorType != "mlm2pro" {
+		t.Errorf("DetectLaunchMonitorType() = %+v for workbook, want mlm2pro", detection)
+	}
+
+	if _, err := DetectLaunchMonitorType("does_not_exist.csv"); err == nil {
+		t.Errorf("Expected error for missing file, got nil")
+	}
+}
+
+func TestScoreSignatures(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "MLM2Pro title row",
+			input:    "\"Rapsodo MLM2PRO: Palmer Little - 09/05/2024 9:27 PM\",,,\n\n\"Club Type\",\"Total Distance\"\n",
+			expected: "mlm2pro",
+		},
+		{
+			name:     "MLM2Pro headers without title",
+			input:    "Club Type,Club Brand,Club Model,Carry Distance,Total Distance,Side Carry,Spin Axis\npw,,,80,85,-2,1\n",
+			expected: "mlm2pro",
+		},
+		{
+			name:     "Garmin headers",
+			input:    "Date,Club Name,Club Type,Backspin,Sidespin,Carry Distance,Carry Deviation Distance,Total Distance,Total Deviation Distance\n,,,[rpm],[rpm],[yds],[yds],[yds],[yds]\n",
+			expected: "garmin",
+		},
+		{
+			name:     "FlightScope headers",
+			input:    "Club,Carry (yds),Total (yds),Lateral (yds),Launch H,Launch V\n",
+			expected: "flightscope",
+		},
+		{
+			name:     "Foresight headers",
+			input:    "Club,Carry,Total,Offline,Azimuth,Club Path,Face Angle,Angle of Attack,Dynamic Loft\n",
+			expected: "foresight",
+		},
+		{
+			name:     "Trackman document",
+			input:    `{"Kind": "RangeSession", "StrokeGroups": []}`,
+			expected: "trackman",
+		},
+		{
+			name:     "GSPro CSV shot log",
+			input:    "Club,Ball Speed,VLA,HLA,Spin Axis,Total Spin,Carry,Offline\n",
+			expected: "gspro",
+		},
+		{
+			name:     "GSPro JSON shot log",
+			input:    `[{"Club": "7I", "BallData": {"Speed": 121.3}, "Carry": 158.2, "Offline": -4.1}]`,
+			expected: "gspro",
+		},
+		{
+			name:    "Shared columns only",
+			input:   "Club,Carry,Total,Offline\n",
+			wantErr: true,
+		},
+		{
+			name:    "Unrelated file",
+			input:   "Name,Age,Score\nPalmer,10,72\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			candidates, err := scoreSignatures(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("scoreSignatures failed: %v", err)
+			}
+
+			detection, err := chooseDetection(candidates)
+			if tt.wantErr {
+				var ambiguous *AmbiguousDetectionError
+				if !errors.As(err, &ambiguous) {
+					t.Fatalf("Expected AmbiguousDetectionError, got %v (detection %+v)", err, detection)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("chooseDetection failed: %v", err)
+			}
+			if detection.LaunchMonitorType != tt.expected {
+				t.Errorf("Detected %q, want %q (candidates %+v)", detection.LaunchMonitorType, tt.expected, candidates)
+			}
+		})
+	}
+}
+
+func TestAmbiguousDetectionError(t *testing.T) {
+	err := &AmbiguousDetectionError{Candidates: []Detection{
+		{LaunchMonitorType: "skytrak", Confidence: 0.57},
+		{LaunchMonitorType: "foresight", Confidence: 0.44},
+	}}
+
+	expected := "could not detect launch monitor type, candidates: skytrak (0.57), foresight (0.44)"
+	if err.Error() != expected {
+		t.Errorf("Error() = %q, want %q", err.Error(), expected)
+	}
+}
@@ -0,0 +1,86 @@
+package parsers
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	"albatross/internal/models"
+)
+
+// Diagnostic records a row or shot that was skipped because it could not be read
+type Diagnostic struct {
+	Line   int    `json:"line"`             // The line of the row, or the position of the shot in a document
+	Column string `json:"column,omitempty"` // The column holding the unreadable value, when known
+	Value  string `json:"value,omitempty"`  // The raw value that could not be read
+	Reason string `json:"reason"`           // Why the row could not be read
+}
+
+func (d Diagnostic) Error() string {
+	if d.Column == "" {
+		return fmt.Sprintf("line %d: %s", d.Line, d.Reason)
+	}
+	return fmt.Sprintf("line %d: column '%s': %s", d.Line, d.Column, d.Reason)
+}
+
+// newDiagnostic describes an error reading the row at a line, taking the column and
+// value from the error when it is a *models.FieldError
+func newDiagnostic(line int, err error) Diagnostic {
+	diagnostic := Diagnostic{Line: line, Reason: err.Error()}
+	var fieldError *models.FieldError
+	if errors.As(err, &fieldError) {
+		diagnostic.Column = fieldError.Field
+		diagnostic.Value = fieldError.Value
+		diagnostic.Reason = fieldError.Reason
+	}
+	return diagnostic
+}
+
+// DiagnosticCount is the number of skipped rows attributed to a column
+type DiagnosticCount struct {
+	Column string // The column, or empty for rows that could not be read at all
+	Count  int
+}
+
+// CountDiagnostics counts the diagnostics for each column, most frequent first
+func CountDiagnostics(diagnostics []Diagnostic) []DiagnosticCount {
+	counts := make(map[string]int)
+	for _, diagnostic := range diagnostics {
+		counts[diagnostic.Column]++
+	}
+
+	summary := make([]DiagnosticCount, 0, len(counts))
+	for column, count := range counts {
+		summary = append(summary, DiagnosticCount{Column: column, Count: count})
+	}
+	sort.Slice(summary, func(i, j int) bool {
+		if summary[i].Count != summary[j].Count {
+			return summary[i].Count > summary[j].Count
+		}
+		return summary[i].Column < summary[j].Column
+	})
+	return summary
+}
+
+// WriteDiagnostics writes diagnostics as CSV with a Line,Column,Value,Reason header
+func WriteDiagnostics(output io.Writer, diagnostics []Diagnostic) error {
+	writer := csv.NewWriter(output)
+	if err := writer.Write([]string{"Line", "Column", "Value", "Reason"}); err != nil {
+		return fmt.Errorf("writing header: %w", err)
+	}
+	for _, diagnostic := range diagnostics {
+		record := []string{strconv.Itoa(diagnostic.Line), diagnostic.Column, diagnostic.Value, diagnostic.Reason}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("writing record: %w", err)
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("flushing records: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package parsers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"albatross/internal/models"
+)
+
+func TestNewDiagnostic(t *testing.T) {
+	fieldError := &models.FieldError{Field: "total", Value: "-", Reason: "missing value"}
+	diagnostic := newDiagnostic(4, fmt.Errorf("processing shot: %w", fieldError))
+	expected := Diagnostic{Line: 4, Column: "total", Value: "-", Reason: "missing value"}
+	if diagnostic != expected {
+		t.Errorf("newDiagnostic() = %+v, want %+v", diagnostic, expected)
+	}
+	if diagnostic.Error() != "line 4: column 'total': missing value" {
+		t.Errorf("Error() = %q", diagnostic.Error())
+	}
+
+	diagnostic = newDiagnostic(7, errors.New("row has 2 columns, expected 5"))
+	expected = Diagnostic{Line: 7, Reason: "row has 2 columns, expected 5"}
+	if diagnostic != expected {
+		t.Errorf("newDiagnostic() = %+v, want %+v", diagnostic, expected)
+	}
+	if diagnostic.Error() != "line 7: row has 2 columns, expected 5" {
+		t.Errorf("Error() = %q", diagnostic.Error())
+	}
+}
+
+func TestCountDiagnostics(t *testing.T) {
+	diagnostics := []Diagnostic{
+		{Line: 2, Column: "total", Reason: "missing value"},
+		{Line: 3, Column: "side", Reason: "missing value"},
+		{Line: 4, Column: "total", Reason: "missing value"},
+		{Line: 5, Reason: "row too short"},
+	}
+
+	expected := []DiagnosticCount{
+		{Column: "total", Count: 2},
+		{Column: "", Count: 1},
+		{Column: "side", Count: 1},
+	}
+	if counts := CountDiagnostics(diagnostics); !reflect.DeepEqual(counts, expected) {
+		t.Errorf("CountDiagnostics() = %+v, want %+v", counts, expected)
+	}
+}
+
+func TestWriteDiagnostics(t *testing.T) {
+	diagnostics := []Diagnostic{
+		{Line: 3, Column: "lateral (yds)", Value: "sideways", Reason: `invalid direction value "sideways"`},
+		{Line: 5, Column: "total (yds)", Value: "-", Reason: "missing value"},
+	}
+
+	var buffer bytes.Buffer
+	if err := WriteDiagnostics(&buffer, diagnostics); err != nil {
+		t.Fatalf("WriteDiagnostics failed: %v", err)
+	}
+
+	expected := `Line,Column,Value,Reason
+3,lateral (yds),sideways,"invalid direction value ""sideways"""
+5,total (yds),-,missing value
+`
+	if buffer.String() != expected {
+		t.Errorf("WriteDiagnostics() wrote\n%s\nwant\n%s", buffer.String(), expected)
+	}
+}
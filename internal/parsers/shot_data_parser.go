@@ -2,61 +2,169 @@
 package parsers
 
 import (
+	"bufio"
 	"encoding/csv"
 	"fmt"
 	"io"
 	"os"
-	"regexp"
+	"path/filepath"
 	"strings"
 
 	"albatross/internal/logging"
 	"albatross/internal/models"
+	"albatross/internal/processors"
 	"albatross/internal/reader"
+	"albatross/internal/xlsx"
 )
 
-// headerPattern is a regular expression used to identify header rows in the CSV file.
-var headerPattern = regexp.MustCompile(`(?i)(club type|total distance|side carry)`)
+// minimumHeaderColumns is the number of signature columns a row must contain to be treated as a header row.
+// Exports don't always include every column, so a header row only needs a few of them.
+const minimumHeaderColumns = 2
 
-// ProcessShotData reads and processes shot data from a CSV file.
-// It supports different launch monitor types and returns a slice of ProcessedShotData.
+// Options configures how an input file is read
+type Options struct {
+	Sheet      string // The XLSX sheet to read, by name or 1-based index; every sheet when empty
+	Strict     bool   // Fail on the first row that cannot be read instead of skipping it
+	InputUnits string // The unit system of exports that don't give their units, models.UnitsImperial or models.UnitsMetric; assumed imperial with a warning when empty
+}
+
+// Result is everything read from an input file
+type Result struct {
+	Session     models.Session
+	Shots       []models.ProcessedShotData
+	Diagnostics []Diagnostic // The rows that were skipped because they could not be read
+}
+
+// rowReader reads one row at a time and returns io.EOF after the last row.
+// Both csv.Reader and xlsx.Reader satisfy it.
+type rowReader interface {
+	Read() ([]string, error)
+}
+
+// ProcessShotData reads and processes shot data from an exported launch monitor file
+// using the default options. See ProcessShotDataWithOptions.
 func ProcessShotData(inputFile string, launchMonitorType string) ([]models.ProcessedShotData, error) {
-	// Open the input file
-	file, err := os.Open(inputFile)
-	if err != nil {
-		return nil, fmt.Errorf("opening file '%s': %w", inputFile, err)
+	return ProcessShotDataWithOptions(inputFile, launchMonitorType, Options{})
+}
+
+// ProcessShotDataWithOptions reads and processes shot data from an exported launch monitor file.
+// It supports different launch monitor types and returns a slice of ProcessedShotData.
+// See ReadFile for the details of how files are read.
+func ProcessShotDataWithOptions(inputFile string, launchMonitorType string, options Options) ([]models.ProcessedShotData, error) {
+	result, err := ReadFile(inputFile, launchMonitorType, options)
+	return result.Shots, err
+}
+
+// ReadFile reads and processes shot data from an exported launch monitor file along with
+// a description of the session and the rows that could not be read. CSV and XLSX exports
+// are read row by row, with title rows above the first header handed to launch monitors
+// that implement models.SessionParser, while JSON documents are handed whole to launch
+// monitors that implement models.DocumentParser. Rows that cannot be read are skipped and
+// recorded as diagnostics, or returned as an error in strict mode. When no row can be read
+// the error is returned along with the result, so its diagnostics can still be reported.
+func ReadFile(inputFile string, launchMonitorType string, options Options) (Result, error) {
+	// Create appropriate launch monitor based on the type
+	registration, ok := reader.Lookup(launchMonitorType)
+	if !ok {
+		return Result{}, fmt.Errorf("unsupported launch monitor type: %s", launchMonitorType)
+	}
+	launchMonitor := registration.New()
+	if _, _, ok := models.SystemUnits(options.InputUnits); options.InputUnits != "" && !ok {
+		return Result{}, fmt.Errorf("unknown input unit system '%s'", options.InputUnits)
+	}
+
+	result := Result{
+		Session: models.Session{
+			Source:            inputFile,
+			LaunchMonitorType: registration.Name,
+			Units:             models.UnitsImperial,
+		},
+	}
+
+	if isWorkbook(inputFile) {
+		rows, err := xlsx.NewReader(inputFile, options.Sheet)
+		if err != nil {
+			return Result{}, err
+		}
+		if err := processRows(rows, launchMonitor, registration.Signature, options, &result); err != nil {
+			return Result{}, err
+		}
+	} else {
+		// Open the input file
+		file, err := os.Open(inputFile)
+		if err != nil {
+			return Result{}, fmt.Errorf("opening file '%s': %w", inputFile, err)
+		}
+		defer file.Close()
+
+		// Launch monitors that export both documents and CSV are routed by the file's contents
+		bufferedFile := bufio.NewReader(file)
+		documentParser, ok := launchMonitor.(models.DocumentParser)
+		if ok && (isDocument(bufferedFile) || len(registration.Signature.Columns) == 0) {
+			err = processDocument(bufferedFile, documentParser, launchMonitor, options, &result)
+		} else {
+			err = processRows(newCSVReader(bufferedFile), launchMonitor, registration.Signature, options, &result)
+		}
+		if err != nil {
+			return Result{}, err
+		}
+	}
+
+	if len(result.Shots) == 0 {
+		return result, fmt.Errorf("no valid data found in the file '%s'", inputFile)
 	}
-	defer file.Close()
 
-	// Set up CSV reader
-	csvReader := csv.NewReader(file)
+	logging.Info("Processed shot data", logging.Fields{
+		"shotsProcessed": len(result.Shots),
+		"rowsSkipped":    len(result.Diagnostics),
+		"file":           inputFile,
+	})
+
+	return result, nil
+}
+
+// newCSVReader sets up a CSV reader that tolerates the quirks of launch monitor exports.
+func newCSVReader(input io.Reader) *csv.Reader {
+	csvReader := csv.NewReader(input)
 	csvReader.Comma = ',' // Using comma as separator
 	csvReader.LazyQuotes = true
 	csvReader.FieldsPerRecord = -1 // Allow variable number of fields
+	return csvReader
+}
 
-	// Create appropriate launch monitor based on the type
-	var launchMonitor models.LaunchMonitor
-	switch launchMonitorType {
-	case "mlm2pro":
-		launchMonitor = reader.NewMLM2ProLaunchMonitor()
-	default:
-		return nil, fmt.Errorf("unsupported launch monitor type: %s", launchMonitorType)
-	}
+// isWorkbook reports whether the input file is an Excel workbook, based on its extension.
+func isWorkbook(inputFile string) bool {
+	return strings.EqualFold(filepath.Ext(inputFile), ".xlsx")
+}
+
+// processRows reads rows, locates the header row of each data block using the
+// launch monitor's signature and processes the shots beneath it into the result until
+// the block ends. Rows above the first header are offered to the launch monitor's
+// SessionParser, if it has one, to fill in the result's session. Values in columns whose
+// header or unit row gives a metric distance or speed unit are converted into yards and mph,
+// or into feet for the signature's height columns. Exports that give no units at all are read in options.InputUnits, and the unit system the
+// export was read in is recorded as the session's InputUnits.
+func processRows(rows rowReader, launchMonitor models.LaunchMonitor, signature reader.Signature, options Options, result *Result) error {
+	sessionParser, parsesSessions := launchMonitor.(models.SessionParser)
 
-	var shotData []models.ProcessedShotData
 	var headers []string
+	var units []reader.ColumnUnit
+	var heights []bool
+	var foundUnits []reader.ColumnUnit
 	inDataBlock := false
+	firstShot := len(result.Shots)
 	lineNumber := 0 // Initialize line number
 
-	// Read and process each row of the CSV file
+	// Read and process each row of the file
 	for {
-		row, err := csvReader.Read()
+		row, err := rows.Read()
 		lineNumber++ // Increment line number for each read operation
 
 		if err != nil {
 			if err == io.EOF {
 				break
 			}
-			return nil, fmt.Errorf("error reading row %d: %w", lineNumber, err)
+			return fmt.Errorf("error reading row %d: %w", lineNumber, err)
 		}
 
 		if len(row) == 0 {
@@ -64,8 +172,11 @@ func ProcessShotData(inputFile string, launchMonitorType string) ([]models.Proce
 		}
 
 		// Check if the current row is a header row
-		if isHeader(row) {
+		if isHeader(row, signature) {
 			headers = normalizeHeaders(row)
+			units = headerUnits(row)
+			heights = heightColumns(headers, signature)
+			foundUnits = appendUnits(foundUnits, units)
 			inDataBlock = true
 			logging.Debug("Found headers", logging.Fields{
 				"line":    lineNumber,
@@ -74,64 +185,240 @@ func ProcessShotData(inputFile string, launchMonitorType string) ([]models.Proce
 			continue
 		}
 
+		// Garmin exports give units in a row beneath the header instead of in the header
+		if inDataBlock && isUnitRow(row) {
+			readUnitRow(row, units)
+			foundUnits = appendUnits(foundUnits, units)
+			continue
+		}
+
+		// Title rows above the first header describe the session
+		if headers == nil && parsesSessions && !isEmptyRow(row) {
+			if sessionParser.ParseSessionRow(row, &result.Session) {
+				logging.Debug("Found session", logging.Fields{
+					"line":    lineNumber,
+					"session": result.Session,
+				})
+			}
+			continue
+		}
+
 		if !inDataBlock {
 			continue
 		}
 
 		// Check if we've reached the end of the data block
-		if isEmptyRow(row) || strings.HasPrefix(strings.ToLower(row[0]), "average") {
+		if isEmptyRow(row) || signature.EndsBlock(row) {
 			inDataBlock = false
 			continue
 		}
 
 		// Parse and process the row data
-		rawData, err := launchMonitor.ParseRow(row, headers)
+		processedData, err := processRow(convertUnits(row, units, heights), headers, launchMonitor)
 		if err != nil {
-			logging.Error(fmt.Sprintf("Skipping row %d due to error", lineNumber), err, logging.Fields{
-				"line": lineNumber,
-				"row":  row,
-			})
+			if err := skip(newDiagnostic(lineNumber, err), options, result); err != nil {
+				return err
+			}
 			continue
 		}
+		result.Shots = append(result.Shots, processedData)
+	}
 
-		processedData := launchMonitor.ProcessRawData(rawData)
-		shotData = append(shotData, processedData)
+	switch {
+	case headers == nil:
+	case len(foundUnits) > 0:
+		result.Session.InputUnits = unitSystem(foundUnits)
+	case signature.Units == reader.UnitsKnown:
+	case options.InputUnits != "":
+		result.Session.InputUnits = strings.ToLower(options.InputUnits)
+		if err := processors.ConvertUnitsToImperial(result.Shots[firstShot:], options.InputUnits); err != nil {
+			return err
+		}
+	default:
+		result.Session.InputUnits = models.UnitsImperial
+		warnAssumedUnits(signature.Units, result.Session)
 	}
+	return nil
+}
 
-	if len(shotData) == 0 {
-		return nil, fmt.Errorf("no valid data found in the file '%s'", inputFile)
+// warnAssumedUnits warns that an export whose units weren't established was read as yards and mph,
+// since a launch monitor app set to metric units would otherwise give silently wrong distances
+func warnAssumedUnits(source reader.UnitSource, session models.Session) {
+	fields := logging.Fields{
+		"file":              session.Source,
+		"launchMonitorType": session.LaunchMonitorType,
 	}
+	if source == reader.UnitsFromHeaders {
+		logging.Warn("No units found in the headers, reading distances as yards and speeds as mph; use -input-units metric for a metric export", fields)
+		return
+	}
+	logging.Warn("The export doesn't give its units, reading distances as yards and speeds as mph; use -input-units metric for a metric export", fields)
+}
 
-	logging.Info("Processed shot data", logging.Fields{
-		"shotsProcessed": len(shotData),
-		"file":           inputFile,
-	})
+// processRow parses a data row and processes it into a shot
+func processRow(row []string, headers []string, launchMonitor models.LaunchMonitor) (models.ProcessedShotData, error) {
+	rawData, err := launchMonitor.ParseRow(row, headers)
+	if err != nil {
+		return models.ProcessedShotData{}, err
+	}
+	return launchMonitor.ProcessRawData(rawData)
+}
+
+// processDocument parses a structured export in one pass and processes every shot it contains into the result.
+// Diagnostics for documents give the position of the shot in the document in place of a line number.
+func processDocument(input io.Reader, documentParser models.DocumentParser, launchMonitor models.LaunchMonitor, options Options, result *Result) error {
+	rawShots, err := documentParser.ParseDocument(input)
+	if err != nil {
+		return fmt.Errorf("parsing document: %w", err)
+	}
+
+	for i, rawData := range rawShots {
+		processedData, err := launchMonitor.ProcessRawData(rawData)
+		if err != nil {
+			if err := skip(newDiagnostic(i+1, err), options, result); err != nil {
+				return err
+			}
+			continue
+		}
+		result.Shots = append(result.Shots, processedData)
+	}
+	return nil
+}
+
+// skip records a row that could not be read in the result, or returns it as an error in strict mode
+func skip(diagnostic Diagnostic, options Options, result *Result) error {
+	if options.Strict {
+		return fmt.Errorf("strict mode: %w", diagnostic)
+	}
 
-	return shotData, nil
+	logging.Debug("Skipping row that could not be read", logging.Fields{
+		"line":   diagnostic.Line,
+		"column": diagnostic.Column,
+		"value":  diagnostic.Value,
+		"reason": diagnostic.Reason,
+	})
+	result.Diagnostics = append(result.Diagnostics, diagnostic)
+	return nil
 }
 
-// isHeader checks if a row is a header row by matching against the headerPattern.
-func isHeader(row []string) bool {
-	if len(row) == 0 {
+// isHeader checks if a row is a header row by matching its cells against the signature's columns.
+func isHeader(row []string, signature reader.Signature) bool {
+	if len(row) == 0 || len(signature.Columns) == 0 {
 		return false
 	}
-	for _, cell := range row {
-		if headerPattern.MatchString(cell) {
-			return true
-		}
+	required := minimumHeaderColumns
+	if len(signature.Columns) < required {
+		required = len(signature.Columns)
 	}
-	return false
+	return signature.MatchColumns(normalizeHeaders(row)) >= required
 }
 
-// normalizeHeaders standardizes header names by converting them to lowercase and trimming whitespace.
+// normalizeHeaders standardizes header names by converting them to lowercase, trimming whitespace
+// and naming the yards or mph that values with a distance or speed unit are converted into.
 func normalizeHeaders(row []string) []string {
 	normalized := make([]string, len(row))
 	for i, header := range row {
-		normalized[i] = strings.ToLower(strings.TrimSpace(header))
+		normalized[i] = reader.NormalizeHeader(header)
 	}
 	return normalized
 }
 
+// headerUnits returns the distance or speed unit each header ends in, if any
+func headerUnits(row []string) []reader.ColumnUnit {
+	units := make([]reader.ColumnUnit, len(row))
+	for i, header := range row {
+		_, units[i], _ = reader.SplitHeaderUnit(strings.ToLower(strings.TrimSpace(header)))
+	}
+	return units
+}
+
+// appendUnits appends the distance and speed units given for columns to found
+func appendUnits(found []reader.ColumnUnit, units []reader.ColumnUnit) []reader.ColumnUnit {
+	for _, unit := range units {
+		if unit != (reader.ColumnUnit{}) {
+			found = append(found, unit)
+		}
+	}
+	return found
+}
+
+// unitSystem names the unit system of the units an export gives: imperial or metric when they
+// all belong to one, or "mixed" when some are imperial and some metric
+func unitSystem(units []reader.ColumnUnit) string {
+	imperial, metric := false, false
+	for _, unit := range units {
+		if unit.IsImperial() || unit.Distance == models.Feet {
+			imperial = true
+		} else {
+			metric = true
+		}
+	}
+	switch {
+	case imperial && metric:
+		return "mixed"
+	case metric:
+		return models.UnitsMetric
+	default:
+		return models.UnitsImperial
+	}
+}
+
+// readUnitRow records the distance and speed units of a unit row such as "[m]" or "[km/h]"
+// for the columns above them
+func readUnitRow(row []string, units []reader.ColumnUnit) {
+	for i, cell := range row {
+		if i >= len(units) {
+			break
+		}
+		if unit, ok := reader.ParseColumnUnit(cell); ok {
+			units[i] = unit
+		}
+	}
+}
+
+// heightColumns reports which of the headers name one of the signature's height columns
+func heightColumns(headers []string, signature reader.Signature) []bool {
+	heights := make([]bool, len(headers))
+	for i, header := range headers {
+		for _, column := range signature.HeightColumns {
+			if header == column {
+				heights[i] = true
+			}
+		}
+	}
+	return heights
+}
+
+// convertUnits returns a copy of a data row with the values of columns in metric distance
+// or speed units converted into yards and mph, and of height columns in any distance unit
+// but feet converted into feet, or the row itself if none need converting
+func convertUnits(row []string, units []reader.ColumnUnit, heights []bool) []string {
+	var converted []string
+	for i, unit := range units {
+		height := i < len(heights) && heights[i]
+		switch {
+		case i >= len(row):
+			continue
+		case height && (unit.Distance == "" || unit.Distance == models.Feet):
+			continue
+		case !height && unit.IsImperial():
+			continue
+		}
+		if converted == nil {
+			converted = append([]string(nil), row...)
+		}
+		if height {
+			converted[i] = unit.ToFeet(strings.TrimSpace(row[i]))
+		} else {
+			converted[i] = unit.ToImperial(strings.TrimSpace(row[i]))
+		}
+	}
+	if converted == nil {
+		return row
+	}
+	return converted
+}
+
 // isEmptyRow checks if a row is empty by verifying that all cells are empty strings when trimmed.
 func isEmptyRow(row []string) bool {
 	for _, cell := range row {
@@ -141,3 +428,20 @@ func isEmptyRow(row []string) bool {
 	}
 	return true
 }
+
+// isUnitRow checks if a row only holds bracketed unit labels such as "[mph]" or "[yds]".
+// Garmin exports place one of these directly beneath the header row.
+func isUnitRow(row []string) bool {
+	hasUnit := false
+	for _, cell := range row {
+		cell = strings.TrimSpace(cell)
+		if cell == "" {
+			continue
+		}
+		if !strings.HasPrefix(cell, "[") || !strings.HasSuffix(cell, "]") {
+			return false
+		}
+		hasUnit = true
+	}
+	return hasUnit
+}
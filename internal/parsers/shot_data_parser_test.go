@@ -1,11 +1,16 @@
 package parsers
 
 import (
+	"errors"
 	"os"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"albatross/internal/models"
+	"albatross/internal/reader"
 )
 
 func TestProcessShotData(t *testing.T) {
@@ -42,20 +47,257 @@ Dr,250,10
 	}
 }
 
-func TestIsHeader(t *testing.T) {
+func TestProcessShotDataGarmin(t *testing.T) {
+	tempFile, err := os.CreateTemp("", "test_garmin_data_*.csv")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	defer os.Remove(tempFile.Name())
+
+	testData := `Date,Club Type,Carry Distance,Carry Deviation Distance,Total Distance
+,,[yds],[yds],[yds]
+09/05/2024,Driver,230.1,-8.2,251.4
+09/05/2024,7 Iron,141.0,3.5,149.6
+`
+	if _, err := tempFile.Write([]byte(testData)); err != nil {
+		t.Fatalf("Failed to write to temp file: %v", err)
+	}
+	tempFile.Close()
+
+	shotData, err := ProcessShotData(tempFile.Name(), "garmin")
+	if err != nil {
+		t.Fatalf("ProcessShotData failed: %v", err)
+	}
+
+	expectedData := []models.ProcessedShotData{
+		{Carry: 230.1, Club: "Dr", Roll: 21.3, Type: "Tee", Total: 251.4, Side: -8.2},
+		{Carry: 141, Club: "7i", Roll: 8.6, Type: "Approach", Total: 149.6, Side: 3.5},
+	}
+
+	if !reflect.DeepEqual(shotData, expectedData) {
+		t.Errorf("ProcessShotData result mismatch.\nGot: %+v\nWant: %+v", shotData, expectedData)
+	}
+}
+
+func TestProcessShotDataFlightScope(t *testing.T) {
+	tempFile, err := os.CreateTemp("", "test_flightscope_data_*.csv")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	defer os.Remove(tempFile.Name())
+
+	testData := `Club,Ball (mph),Carry (yds),Total (yds),Lateral (yds)
+7 Iron,112.4,141.2,150.8,5.2 L
+7 Iron,110.9,139.8,148.1,3.1 R
+7 Iron,111.0,140.0,149.0,sideways
+`
+	if _, err := tempFile.Write([]byte(testData)); err != nil {
+		t.Fatalf("Failed to write to temp file: %v", err)
+	}
+	tempFile.Close()
+
+	shotData, err := ProcessShotData(tempFile.Name(), "flightscope")
+	if err != nil {
+		t.Fatalf("ProcessShotData failed: %v", err)
+	}
+
+	expectedData := []models.ProcessedShotData{
+		{Carry: 141.2, Club: "7i", Roll: 9.6, Type: "Approach", Total: 150.8, Side: -5.2, BallSpeed: 112.4},
+		{Carry: 139.8, Club: "7i", Roll: 8.3, Type: "Approach", Total: 148.1, Side: 3.1, BallSpeed: 110.9},
+	}
+
+	if !reflect.DeepEqual(shotData, expectedData) {
+		t.Errorf("ProcessShotData result mismatch.\nGot: %+v\nWant: %+v", shotData, expectedData)
+	}
+}
+
+func TestProcessShotDataForesight(t *testing.T) {
+	tempFile, err := os.CreateTemp("", "test_foresight_data_*.csv")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	defer os.Remove(tempFile.Name())
+
+	testData := `Club,Ball Speed,Carry,Total,Offline,Club Path,Face Angle,Angle of Attack,Dynamic Loft
+Driver,158.2,241.0,262.4,8.1 R,2.3 L,-1.1,3.2,13.4
+`
+	if _, err := tempFile.Write([]byte(testData)); err != nil {
+		t.Fatalf("Failed to write to temp file: %v", err)
+	}
+	tempFile.Close()
+
+	shotData, err := ProcessShotData(tempFile.Name(), "foresight")
+	if err != nil {
+		t.Fatalf("ProcessShotData failed: %v", err)
+	}
+
+	expectedData := []models.ProcessedShotData{
+		{Carry: 241, Club: "Dr", Roll: 21.4, Type: "Tee", Total: 262.4, Side: 8.1, BallSpeed: 158.2, ClubPath: -2.3, FaceAngle: -1.1, AttackAngle: 3.2, DynamicLoft: 13.4},
+	}
+
+	if !reflect.DeepEqual(shotData, expectedData) {
+		t.Errorf("ProcessShotData result mismatch.\nGot: %+v\nWant: %+v", shotData, expectedData)
+	}
+}
+
+func TestProcessShotDataTrackman(t *testing.T) {
+	tempFile, err := os.CreateTemp("", "test_trackman_data_*.json")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	defer os.Remove(tempFile.Name())
+
+	testData := `{"StrokeGroups": [{"Club": "Driver", "Strokes": [
+		{"Measurement": {"Total": 200, "Side": 10}},
+		{"Measurement": {"Total": 220, "Side": -5}}
+	]}]}`
+	if _, err := tempFile.Write([]byte(testData)); err != nil {
+		t.Fatalf("Failed to write to temp file: %v", err)
+	}
+	tempFile.Close()
+
+	shotData, err := ProcessShotData(tempFile.Name(), "trackman")
+	if err != nil {
+		t.Fatalf("ProcessShotData failed: %v", err)
+	}
+
+	if len(shotData) != 2 {
+		t.Fatalf("Expected 2 shots, got %d", len(shotData))
+	}
+	if shotData[0].Club != "Dr" || shotData[0].Type != "Tee" {
+		t.Errorf("Unexpected club or type: %+v", shotData[0])
+	}
+	if shotData[1].Total <= 240 || shotData[1].Side >= -5 {
+		t.Errorf("Expected distances to be converted from meters to yards, got %+v", shotData[1])
+	}
+}
+
+func TestProcessShotDataWithOptionsWorkbook(t *testing.T) {
+	shotData, err := ProcessShotDataWithOptions("../../examples/input/mlm2pro.xlsx", "mlm2pro", Options{Sheet: "Session"})
+	if err != nil {
+		t.Fatalf("ProcessShotDataWithOptions failed: %v", err)
+	}
+	if len(shotData) != 56 {
+		t.Errorf("Expected 56 shots, got %d", len(shotData))
+	}
+
+	if _, err := ProcessShotDataWithOptions("../../examples/input/mlm2pro.xlsx", "mlm2pro", Options{Sheet: "Missing"}); err == nil {
+		t.Errorf("Expected error for missing sheet, got nil")
+	}
+}
+
+func TestProcessRowsWithProfile(t *testing.T) {
+	profile, err := reader.LoadProfile("../../examples/profiles/uneekor.json")
+	if err != nil {
+		t.Fatalf("LoadProfile failed: %v", err)
+	}
+	registration := profile.Registration()
+
+	testData := `Club,Carry,Total,Offline
+Driver,228.0,250.0,5.0 R
+Driver,230.0,254.0,2.0 L
+Average,229.0,252.0,1.5 R
+Std. Dev.,1.0,2.0,3.5
+Club,Carry,Total,Offline
+PW,101.0,108.0,1.0 L
+Std. Dev.,0.0,0.0,0.0
+`
+
+	var result Result
+	err = processRows(newCSVReader(strings.NewReader(testData)), registration.New(), registration.Signature, Options{}, &result)
+	if err != nil {
+		t.Fatalf("processRows failed: %v", err)
+	}
+	shotData := result.Shots
+
+	expectedData := []models.ProcessedShotData{
+		{Carry: 228, Club: "Dr", Roll: 22, Type: "Tee", Total: 250, Side: 5},
+		{Carry: 230, Club: "Dr", Roll: 24, Type: "Tee", Total: 254, Side: -2},
+		{Carry: 101, Club: "Pw", Roll: 7, Type: "Approach", Total: 108, Side: -1},
+	}
+
+	if !reflect.DeepEqual(shotData, expectedData) {
+		t.Errorf("processRows result mismatch.\nGot: %+v\nWant: %+v", shotData, expectedData)
+	}
+}
+
+func TestProcessShotDataGSPro(t *testing.T) {
 	tests := []struct {
 		name     string
-		row      []string
-		expected bool
+		pattern  string
+		testData string
+		expected models.ProcessedShotData
 	}{
-		{"Valid header", []string{"Club Type", "Total Distance", "Side Carry"}, true},
-		{"Invalid header", []string{"Name", "Age", "Score"}, false},
-		{"Empty row", []string{}, false},
+		{
+			name:     "CSV shot log",
+			pattern:  "test_gspro_data_*.csv",
+			testData: "Club,Ball Speed,VLA,HLA,Spin Axis,Total Spin,Carry,Offline\n7I,121.3,17.8,1.2 L,-2.5,6350,158.2,4.1 L\n",
+			expected: models.ProcessedShotData{
+				Carry: 158.2, Club: "7i", Type: "Approach", Total: 158.2, Side: -4.1,
+				BallSpeed: 121.3, LaunchAngle: 17.8, LaunchDirection: -1.2, SpinRate: 6350, SpinAxis: -2.5,
+			},
+		},
+		{
+			name:     "JSON shot log",
+			pattern:  "test_gspro_data_*.json",
+			testData: `[{"Club": "7I", "BallData": {"Speed": 121.3, "HLA": -1.2}, "ClubData": {"Speed": 87.2}, "Carry": 158.2, "Offline": -4.1}]`,
+			expected: models.ProcessedShotData{
+				Carry: 158.2, Club: "7i", Type: "Approach", Total: 158.2, Side: -4.1,
+				BallSpeed: 121.3, LaunchDirection: -1.2, ClubSpeed: 87.2, SmashFactor: 1.39,
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := isHeader(tt.row)
+			tempFile, err := os.CreateTemp("", tt.pattern)
+			if err != nil {
+				t.Fatalf("Failed to create temp file: %v", err)
+			}
+			defer os.Remove(tempFile.Name())
+
+			if _, err := tempFile.Write([]byte(tt.testData)); err != nil {
+				t.Fatalf("Failed to write to temp file: %v", err)
+			}
+			tempFile.Close()
+
+			shotData, err := ProcessShotData(tempFile.Name(), "gspro")
+			if err != nil {
+				t.Fatalf("ProcessShotData failed: %v", err)
+			}
+
+			expectedData := []models.ProcessedShotData{tt.expected}
+			if !reflect.DeepEqual(shotData, expectedData) {
+				t.Errorf("ProcessShotData result mismatch.\nGot: %+v\nWant: %+v", shotData, expectedData)
+			}
+		})
+	}
+}
+
+func TestIsHeader(t *testing.T) {
+	mlm2pro, _ := reader.Lookup("mlm2pro")
+	flightScope, _ := reader.Lookup("flightscope")
+	skyTrak, _ := reader.Lookup("skytrak")
+
+	tests := []struct {
+		name      string
+		row       []string
+		signature reader.Signature
+		expected  bool
+	}{
+		{"Valid header", []string{"Club Type", "Total Distance", "Side Carry"}, mlm2pro.Signature, true},
+		{"Header with units", []string{"Club", "Total (yds)", "Lateral (yds)"}, flightScope.Signature, true},
+		{"Offline header", []string{"Club", "Carry", "Total", "Offline"}, skyTrak.Signature, true},
+		{"Invalid header", []string{"Name", "Age", "Score"}, mlm2pro.Signature, false},
+		{"Single matching column", []string{"Club Type", "Age", "Score"}, mlm2pro.Signature, false},
+		{"Data row", []string{"pw", "U.S. Kids Golf", "85.9"}, mlm2pro.Signature, false},
+		{"Empty row", []string{}, mlm2pro.Signature, false},
+		{"Document signature", []string{"Club", "Total"}, reader.Signature{DocumentKeys: []string{"strokegroups"}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := isHeader(tt.row, tt.signature)
 			if result != tt.expected {
 				t.Errorf("isHeader(%v) = %v, want %v", tt.row, result, tt.expected)
 			}
@@ -93,3 +335,304 @@ func TestIsEmptyRow(t *testing.T) {
 		})
 	}
 }
+
+func TestIsUnitRow(t *testing.T) {
+	tests := []struct {
+		name     string
+		row      []string
+		expected bool
+	}{
+		{"Unit row", []string{"", "[mph]", "[deg]", "", "[yds]"}, true},
+		{"Data row", []string{"Driver", "[mph]", "250"}, false},
+		{"Empty row", []string{"", " "}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := isUnitRow(tt.row)
+			if result != tt.expected {
+				t.Errorf("isUnitRow(%v) = %v, want %v", tt.row, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAppendUnits(t *testing.T) {
+	if found := appendUnits(nil, headerUnits([]string{"Club", "Carry Distance", "Total Distance"})); len(found) != 0 {
+		t.Errorf("Expected no units in headers without them, got %v", found)
+	}
+	if found := appendUnits(nil, headerUnits([]string{"Club", "Carry (m)", "Total (m)"})); len(found) != 2 {
+		t.Errorf("Expected units in headers ending in (m), got %v", found)
+	}
+
+	units := headerUnits([]string{"Club", "Carry Distance"})
+	readUnitRow([]string{"", "[m]"}, units)
+	if found := appendUnits(nil, units); len(found) != 1 {
+		t.Errorf("Expected units from a unit row, got %v", found)
+	}
+}
+
+func TestUnitSystem(t *testing.T) {
+	tests := []struct {
+		name     string
+		headers  []string
+		expected string
+	}{
+		{"Imperial", []string{"Carry (yds)", "Ball (mph)"}, models.UnitsImperial},
+		{"Metric", []string{"Carry (m)", "Ball (km/h)"}, models.UnitsMetric},
+		{"Mixed", []string{"Carry (m)", "Ball (mph)"}, "mixed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := unitSystem(appendUnits(nil, headerUnits(tt.headers))); result != tt.expected {
+				t.Errorf("unitSystem(%v) = %q, want %q", tt.headers, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestReadFile(t *testing.T) {
+	startTime := time.Date(2024, 9, 5, 21, 27, 0, 0, time.Local)
+	for _, inputFile := range []string{"../../examples/input/mlm2pro.csv", "../../examples/input/mlm2pro.xlsx"} {
+		t.Run(filepath.Ext(inputFile), func(t *testing.T) {
+			result, err := ReadFile(inputFile, "rapsodo", Options{})
+			if err != nil {
+				t.Fatalf("ReadFile failed: %v", err)
+			}
+			if len(result.Shots) != 56 {
+				t.Errorf("Expected 56 shots, got %d", len(result.Shots))
+			}
+			if len(result.Diagnostics) != 0 {
+				t.Errorf("Expected no diagnostics, got %+v", result.Diagnostics)
+			}
+
+			expected := models.Session{
+				Device:            "Rapsodo MLM2PRO",
+				Player:            "Palmer Little",
+				StartTime:         &startTime,
+				Source:            inputFile,
+				LaunchMonitorType: "mlm2pro",
+				Units:             models.UnitsImperial,
+				InputUnits:        models.UnitsImperial,
+			}
+			if !reflect.DeepEqual(result.Session, expected) {
+				t.Errorf("ReadFile() session = %+v, want %+v", result.Session, expected)
+			}
+		})
+	}
+}
+
+func TestReadFileDiagnostics(t *testing.T) {
+	tempFile, err := os.CreateTemp("", "test_flightscope_data_*.csv")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	defer os.Remove(tempFile.Name())
+
+	testData := `Club,Ball (mph),Carry (yds),Total (yds),Lateral (yds)
+7 Iron,112.4,141.2,150.8,5.2 L
+7 Iron,111.0,140.0,149.0,sideways
+7 Iron,fast,139.8,148.1,3.1 R
+7 Iron,110.9,139.8,-,3.1 R
+`
+	if _, err := tempFile.Write([]byte(testData)); err != nil {
+		t.Fatalf("Failed to write to temp file: %v", err)
+	}
+	tempFile.Close()
+
+	t.Run("Lenient", func(t *testing.T) {
+		result, err := ReadFile(tempFile.Name(), "flightscope", Options{})
+		if err != nil {
+			t.Fatalf("ReadFile failed: %v", err)
+		}
+		if len(result.Shots) != 1 {
+			t.Errorf("Expected 1 shot, got %d", len(result.Shots))
+		}
+
+		expected := []Diagnostic{
+			{Line: 3, Column: "lateral (yds)", Value: "sideways", Reason: `invalid direction value "sideways"`},
+			{Line: 4, Column: "ball (mph)", Value: "fast", Reason: `invalid number "fast"`},
+			{Line: 5, Column: "total (yds)", Value: "-", Reason: "missing value"},
+		}
+		if !reflect.DeepEqual(result.Diagnostics, expected) {
+			t.Errorf("ReadFile() diagnostics = %+v, want %+v", result.Diagnostics, expected)
+		}
+	})
+
+	t.Run("No valid rows", func(t *testing.T) {
+		invalidFile, err := os.CreateTemp("", "test_flightscope_data_*.csv")
+		if err != nil {
+			t.Fatalf("Failed to create temp file: %v", err)
+		}
+		defer os.Remove(invalidFile.Name())
+		if _, err := invalidFile.WriteString("Club,Ball (mph),Carry (yds),Total (yds),Lateral (yds)\n7 Iron,fast,139.8,148.1,3.1 R\n"); err != nil {
+			t.Fatalf("Failed to write to temp file: %v", err)
+		}
+		invalidFile.Close()
+
+		// The diagnostics say why no row could be read
+		result, err := ReadFile(invalidFile.Name(), "flightscope", Options{})
+		if err == nil {
+			t.Fatalf("Expected error for a file without valid rows, got nil")
+		}
+		expected := []Diagnostic{{Line: 2, Column: "ball (mph)", Value: "fast", Reason: `invalid number "fast"`}}
+		if !reflect.DeepEqual(result.Diagnostics, expected) {
+			t.Errorf("ReadFile() diagnostics = %+v, want %+v", result.Diagnostics, expected)
+		}
+	})
+
+	t.Run("Strict", func(t *testing.T) {
+		_, err := ReadFile(tempFile.Name(), "flightscope", Options{Strict: true})
+		var diagnostic Diagnostic
+		if !errors.As(err, &diagnostic) {
+			t.Fatalf("ReadFile() error = %v, want a Diagnostic", err)
+		}
+		if diagnostic.Line != 3 || diagnostic.Column != "lateral (yds)" {
+			t.Errorf("ReadFile() diagnostic = %+v, want line 3 column 'lateral (yds)'", diagnostic)
+		}
+	})
+}
+
+func TestReadFileInputUnits(t *testing.T) {
+	tempFile, err := os.CreateTemp("", "test_mlm2pro_data_*.csv")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	defer os.Remove(tempFile.Name())
+
+	// An MLM2Pro export from an app set to metric units, which the export doesn't say
+	testData := `"Club Type","Carry Distance","Total Distance","Ball Speed","Side Carry","Club Speed"
+"7i","129.1","137.9","180.9","-4.8","128.7"
+`
+	if _, err := tempFile.Write([]byte(testData)); err != nil {
+		t.Fatalf("Failed to write to temp file: %v", err)
+	}
+	tempFile.Close()
+
+	result, err := ReadFile(tempFile.Name(), "mlm2pro", Options{InputUnits: models.UnitsMetric})
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	expected := []models.ProcessedShotData{
+		{Carry: 141.19, Club: "7i", Roll: 9.62, Type: "Approach", Total: 150.81, Side: -5.25, BallSpeed: 112.41, ClubSpeed: 79.97, SmashFactor: 1.41},
+	}
+	if !reflect.DeepEqual(result.Shots, expected) {
+		t.Errorf("ReadFile() shots = %+v, want %+v", result.Shots, expected)
+	}
+	if result.Session.InputUnits != models.UnitsMetric {
+		t.Errorf("ReadFile() session input units = %q, want %q", result.Session.InputUnits, models.UnitsMetric)
+	}
+
+	// Headers that give their units take precedence over the input units
+	headerFile, err := os.CreateTemp("", "test_flightscope_data_*.csv")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	defer os.Remove(headerFile.Name())
+	if _, err := headerFile.WriteString("Club,Ball (mph),Carry (yds),Total (yds),Lateral (yds)\n7 Iron,112.4,141.2,150.8,5.2 L\n"); err != nil {
+		t.Fatalf("Failed to write to temp file: %v", err)
+	}
+	headerFile.Close()
+
+	result, err = ReadFile(headerFile.Name(), "flightscope", Options{InputUnits: models.UnitsMetric})
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	if len(result.Shots) != 1 || result.Shots[0].Carry != 141.2 {
+		t.Errorf("ReadFile() shots = %+v, want a 141.2 yard carry", result.Shots)
+	}
+	if result.Session.InputUnits != models.UnitsImperial {
+		t.Errorf("ReadFile() session input units = %q, want %q", result.Session.InputUnits, models.UnitsImperial)
+	}
+
+	if _, err := ReadFile(tempFile.Name(), "mlm2pro", Options{InputUnits: "furlongs"}); err == nil {
+		t.Errorf("Expected error for an unknown input unit system, got nil")
+	}
+}
+
+func TestReadFileHeightColumns(t *testing.T) {
+	tempFile, err := os.CreateTemp("", "test_garmin_data_*.csv")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	defer os.Remove(tempFile.Name())
+
+	// Garmin gives apex height in feet beneath the yards of its distances, which is imperial too
+	testData := `Club Type,Carry Distance,Total Distance,Carry Deviation Distance,Apex Height
+,[yds],[yds],[yds],[ft]
+7 Iron,141.2,150.8,-5.2,89.9
+`
+	if _, err := tempFile.Write([]byte(testData)); err != nil {
+		t.Fatalf("Failed to write to temp file: %v", err)
+	}
+	tempFile.Close()
+
+	result, err := ReadFile(tempFile.Name(), "garmin", Options{})
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	if len(result.Shots) != 1 || result.Shots[0].Apex != 89.9 || result.Shots[0].Carry != 141.2 {
+		t.Errorf("ReadFile() shots = %+v, want an 89.9 foot apex and a 141.2 yard carry", result.Shots)
+	}
+	if result.Session.InputUnits != models.UnitsImperial {
+		t.Errorf("ReadFile() session input units = %q, want %q", result.Session.InputUnits, models.UnitsImperial)
+	}
+}
+
+func TestProcessShotDataMetricUnits(t *testing.T) {
+	tests := []struct {
+		name     string
+		pattern  string
+		monitor  string
+		testData string
+		expected []models.ProcessedShotData
+	}{
+		{
+			name:    "Units in headers",
+			pattern: "test_flightscope_data_*.csv",
+			monitor: "flightscope",
+			testData: `Club,Ball (km/h),Carry (m),Total (m),Lateral (m)
+7 Iron,180.9,129.1,137.9,4.8 L
+`,
+			expected: []models.ProcessedShotData{
+				{Carry: 141.19, Club: "7i", Roll: 9.62, Type: "Approach", Total: 150.81, Side: -5.25, BallSpeed: 112.41},
+			},
+		},
+		{
+			name:    "Unit row beneath headers",
+			pattern: "test_garmin_data_*.csv",
+			monitor: "garmin",
+			testData: `Club Type,Carry Distance,Total Distance,Carry Deviation Distance,Ball Speed,Apex Height
+,[m],[m],[m],[km/h],[m]
+7 Iron,129.1,137.9,-4.8,180.9,27.4
+`,
+			expected: []models.ProcessedShotData{
+				{Apex: 89.9, Carry: 141.19, Club: "7i", Roll: 9.62, Type: "Approach", Total: 150.81, Side: -5.25, BallSpeed: 112.41},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempFile, err := os.CreateTemp("", tt.pattern)
+			if err != nil {
+				t.Fatalf("Failed to create temp file: %v", err)
+			}
+			defer os.Remove(tempFile.Name())
+
+			if _, err := tempFile.Write([]byte(tt.testData)); err != nil {
+				t.Fatalf("Failed to write to temp file: %v", err)
+			}
+			tempFile.Close()
+
+			shotData, err := ProcessShotData(tempFile.Name(), tt.monitor)
+			if err != nil {
+				t.Fatalf("ProcessShotData failed: %v", err)
+			}
+			if !reflect.DeepEqual(shotData, tt.expected) {
+				t.Errorf("ProcessShotData result mismatch.\nGot: %+v\nWant: %+v", shotData, tt.expected)
+			}
+		})
+	}
+}
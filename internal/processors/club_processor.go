@@ -2,6 +2,8 @@ package processors
 
 import (
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"unicode"
 )
@@ -77,6 +79,62 @@ func DetermineShotType(clubType string) string {
 	return "Approach" // Default to Approach if no match is found
 }
 
+// wedgeLofts holds the typical lofts of wedges in degrees, keyed by normalized club type
+var wedgeLofts = map[string]float64{"Pw": 46, "Gw": 50, "Sw": 56, "Lw": 60}
+
+// minWedgeLoft is the smallest number of a "W" club read as a wedge's loft, such as the "52W"
+// a "52 Wedge" normalizes to, rather than as a wood's number
+const minWedgeLoft = 40
+
+// ClubLoft returns the typical loft in degrees of a normalized club type, such as 10.5 for "Dr"
+// or 34 for "7i". Lofts vary between manufacturers, so they only order clubs; it reports false
+// for club types it doesn't know, such as "Putter". Wedges named by their loft, such as "52W",
+// have that loft.
+func ClubLoft(clubType string) (float64, bool) {
+	if clubType == "Dr" {
+		return 10.5, true
+	}
+	if loft, ok := wedgeLofts[clubType]; ok {
+		return loft, true
+	}
+	if number, ok := strings.CutSuffix(clubType, "W"); ok {
+		if value, err := strconv.Atoi(number); err == nil && value >= minWedgeLoft {
+			return float64(value), true
+		}
+	}
+
+	// Numbered clubs gain loft with their number at a rate that differs by kind
+	for suffix, loft := range map[string]func(number float64) float64{
+		"W":  func(number float64) float64 { return 10.5 + 1.5*number }, // 3W 15, 5W 18, 7W 21
+		"Hy": func(number float64) float64 { return 10 + 3*number },     // 3Hy 19, 4Hy 22, 5Hy 25
+		"i":  func(number float64) float64 { return 6 + 4*number },      // 4i 22, 7i 34, 9i 42
+	} {
+		if number, ok := strings.CutSuffix(clubType, suffix); ok {
+			if value, err := strconv.Atoi(number); err == nil {
+				return loft(float64(value)), true
+			}
+		}
+	}
+	return 0, false
+}
+
+// SortClubs orders normalized club types from the least lofted, longest club to the most
+// lofted, such as Dr, 3W, 5W, 4Hy, 5i ... Pw, Gw, Sw, Lw. Clubs of equal loft are ordered by
+// name, and club types without a known loft come last in name order.
+func SortClubs(clubTypes []string) {
+	sort.SliceStable(clubTypes, func(i, j int) bool {
+		loftI, knownI := ClubLoft(clubTypes[i])
+		loftJ, knownJ := ClubLoft(clubTypes[j])
+		if knownI != knownJ {
+			return knownI
+		}
+		if loftI != loftJ {
+			return loftI < loftJ
+		}
+		return clubTypes[i] < clubTypes[j]
+	})
+}
+
 // extractNumber retrieves the first numeric value from a string.
 // For example, "3wood" would return "3".
 func extractNumber(s string) string {
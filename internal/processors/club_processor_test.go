@@ -1,6 +1,7 @@
 package processors
 
 import (
+	"reflect"
 	"testing"
 )
 
@@ -26,6 +27,7 @@ func TestNormalizeClubType(t *testing.T) {
 		{"Sand Wedge", "SW", "Sw"},
 		{"Gap Wedge", "Gap Wedge", "Gw"},
 		{"Lob Wedge", "LobWedge", "Lw"},
+		{"Wedge by loft", "52 Wedge", "52W"},
 		{"Putter", "putter", "Putter"},
 	}
 
@@ -135,3 +137,38 @@ func BenchmarkDetermineShotType(b *testing.B) {
 		}
 	}
 }
+
+func TestClubLoft(t *testing.T) {
+	tests := []struct {
+		club     string
+		expected float64
+		known    bool
+	}{
+		{"Dr", 10.5, true},
+		{"3W", 15, true},
+		{"4Hy", 22, true},
+		{"7i", 34, true},
+		{"Sw", 56, true},
+		{"52W", 52, true},
+		{"Putter", 0, false},
+		{"Xi", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.club, func(t *testing.T) {
+			loft, known := ClubLoft(tt.club)
+			if loft != tt.expected || known != tt.known {
+				t.Errorf("ClubLoft(%q) = %v, %v, want %v, %v", tt.club, loft, known, tt.expected, tt.known)
+			}
+		})
+	}
+}
+
+func TestSortClubs(t *testing.T) {
+	clubs := []string{"Lw", "7i", "Putter", "56W", "Pw", "4Hy", "Dr", "5W", "4i", "52W", "3W", "5i"}
+	SortClubs(clubs)
+	expected := []string{"Dr", "3W", "5W", "4Hy", "4i", "5i", "7i", "Pw", "52W", "56W", "Lw", "Putter"}
+	if !reflect.DeepEqual(clubs, expected) {
+		t.Errorf("SortClubs() = %v, want %v", clubs, expected)
+	}
+}
@@ -0,0 +1,47 @@
+package processors
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseDirection converts a directional value into a signed number, with left
+// negative and right positive. It accepts plain signed numbers ("-5.2") as well
+// as values with a single "L" or "R" suffix or prefix ("5.2 L", "3.1R", "L 5.2").
+// Signed numbers with a marker ("-3.1 R") are rejected as ambiguous.
+func ParseDirection(value string) (float64, error) {
+	trimmed := strings.ToUpper(strings.TrimSpace(value))
+	if trimmed == "" {
+		return 0, fmt.Errorf("empty direction value")
+	}
+
+	sign := 1.0
+	marked := true
+	switch {
+	case strings.HasSuffix(trimmed, "L"):
+		sign = -1.0
+		trimmed = strings.TrimSuffix(trimmed, "L")
+	case strings.HasPrefix(trimmed, "L"):
+		sign = -1.0
+		trimmed = strings.TrimPrefix(trimmed, "L")
+	case strings.HasSuffix(trimmed, "R"):
+		trimmed = strings.TrimSuffix(trimmed, "R")
+	case strings.HasPrefix(trimmed, "R"):
+		trimmed = strings.TrimPrefix(trimmed, "R")
+	default:
+		marked = false
+	}
+
+	trimmed = strings.TrimSpace(trimmed)
+	number, err := strconv.ParseFloat(trimmed, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid direction value %q", value)
+	}
+	// A sign and a marker could each be the direction, so neither can be trusted
+	if marked && (strings.HasPrefix(trimmed, "-") || strings.HasPrefix(trimmed, "+")) {
+		return 0, fmt.Errorf("ambiguous direction value %q", value)
+	}
+
+	return sign * number, nil
+}
@@ -0,0 +1,43 @@
+package processors
+
+import (
+	"testing"
+)
+
+func TestParseDirection(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected float64
+		wantErr  bool
+	}{
+		{"Left suffix", "5.2 L", -5.2, false},
+		{"Right suffix", "3.1 R", 3.1, false},
+		{"No space", "4.0L", -4.0, false},
+		{"Lowercase", "2.5 r", 2.5, false},
+		{"Left prefix", "L 1.5", -1.5, false},
+		{"Signed number", "-7.3", -7.3, false},
+		{"Unsigned number", "7.3", 7.3, false},
+		{"Zero", "0", 0, false},
+		{"Empty", "", 0, true},
+		{"Dash", "-", 0, true},
+		{"Garbage", "left", 0, true},
+		{"Negative left", "-5.2 L", 0, true},
+		{"Negative right", "-3.1 R", 0, true},
+		{"Positive right", "+3.1 R", 0, true},
+		{"Doubled marker", "LL5", 0, true},
+		{"Both markers", "L5R", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseDirection(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseDirection(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if result != tt.expected {
+				t.Errorf("ParseDirection(%q) = %v, want %v", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
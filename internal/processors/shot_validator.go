@@ -0,0 +1,76 @@
+package processors
+
+import (
+	"fmt"
+	"math"
+
+	"albatross/internal/models"
+)
+
+// Limits of physically plausible launch monitor readings. Shots outside them are
+// most likely misreads, such as the MLM2Pro picking up the club instead of the ball.
+const (
+	maxSmashFactor = 1.55  // Ball speed can't be much more than 1.5 times club speed
+	maxSpinRate    = 15000 // Even a flop shot spins well under this
+	minLaunchAngle = -10.0 // Degrees; a topped shot launches just below level
+	maxLaunchAngle = 60.0  // Degrees; steeper than a lob wedge can launch
+)
+
+// ValidateShots flags physically implausible shots as likely misreads, adding the reasons
+// to each shot's Flags. Zero spin is only flagged when other shots in the data report spin,
+// since not every launch monitor measures it. It returns the number of shots flagged.
+func ValidateShots(shotData []models.ProcessedShotData) int {
+	spinReported := false
+	for _, shot := range shotData {
+		if shot.SpinRate > 0 {
+			spinReported = true
+			break
+		}
+	}
+
+	flagged := 0
+	for i := range shotData {
+		for _, reason := range validateShot(shotData[i], spinReported) {
+			shotData[i].Flags = appendFlag(shotData[i].Flags, reason)
+		}
+		if len(shotData[i].Flags) > 0 {
+			flagged++
+		}
+	}
+	return flagged
+}
+
+// validateShot returns the reasons a shot looks like a misread, if any
+func validateShot(shot models.ProcessedShotData, spinReported bool) []string {
+	var reasons []string
+	if shot.SmashFactor > maxSmashFactor {
+		reasons = append(reasons, fmt.Sprintf("smash factor %.2f above %.2f", shot.SmashFactor, maxSmashFactor))
+	}
+	if spinReported && shot.SpinRate == 0 && shot.BallSpeed > 0 {
+		reasons = append(reasons, "zero spin")
+	}
+	if shot.SpinRate > maxSpinRate {
+		reasons = append(reasons, fmt.Sprintf("spin rate %.0f above %d", shot.SpinRate, maxSpinRate))
+	}
+	if shot.Carry > 0 && shot.Total > 0 && shot.Carry > shot.Total {
+		reasons = append(reasons, "carry greater than total")
+	}
+	if shot.Carry > 0 && math.Abs(shot.Side) > shot.Carry {
+		reasons = append(reasons, "side carry greater than carry")
+	}
+	if shot.LaunchAngle < minLaunchAngle || shot.LaunchAngle > maxLaunchAngle {
+		reasons = append(reasons, fmt.Sprintf("launch angle %.1f outside %.0f to %.0f", shot.LaunchAngle, minLaunchAngle, maxLaunchAngle))
+	}
+	return reasons
+}
+
+// appendFlag adds a reason to a shot's flags unless it is already there, so shots
+// read back in from a processed file can be validated again
+func appendFlag(flags []string, reason string) []string {
+	for _, flag := range flags {
+		if flag == reason {
+			return flags
+		}
+	}
+	return append(flags, reason)
+}
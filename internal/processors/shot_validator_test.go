@@ -0,0 +1,57 @@
+package processors
+
+import (
+	"reflect"
+	"testing"
+
+	"albatross/internal/models"
+)
+
+func TestValidateShots(t *testing.T) {
+	shotData := []models.ProcessedShotData{
+		{Club: "7i", Carry: 140, Total: 150, Side: 3, BallSpeed: 112, SmashFactor: 1.33, LaunchAngle: 17, SpinRate: 6500},
+		{Club: "7i", Carry: 140, Total: 150, Side: 3, BallSpeed: 112, SmashFactor: 1.62, LaunchAngle: 17, SpinRate: 6500},
+		{Club: "7i", Carry: 140, Total: 150, Side: 3, BallSpeed: 112, SmashFactor: 1.33, LaunchAngle: 17},
+		{Club: "7i", Carry: 140, Total: 150, Side: 3, BallSpeed: 112, SmashFactor: 1.33, LaunchAngle: 17, SpinRate: 18000},
+		{Club: "7i", Carry: 155, Total: 150, Side: 3, BallSpeed: 112, SmashFactor: 1.33, LaunchAngle: 17, SpinRate: 6500},
+		{Club: "7i", Carry: 40, Total: 50, Side: -45, BallSpeed: 112, SmashFactor: 1.33, LaunchAngle: 17, SpinRate: 6500},
+		{Club: "7i", Carry: 140, Total: 150, Side: 3, BallSpeed: 112, SmashFactor: 1.33, LaunchAngle: 72, SpinRate: 6500},
+		{Club: "Pw", Total: 100, Side: 2}, // No launch data
+	}
+	expected := [][]string{
+		nil,
+		{"smash factor 1.62 above 1.55"},
+		{"zero spin"},
+		{"spin rate 18000 above 15000"},
+		{"carry greater than total"},
+		{"side carry greater than carry"},
+		{"launch angle 72.0 outside -10 to 60"},
+		nil,
+	}
+
+	if flagged := ValidateShots(shotData); flagged != 6 {
+		t.Errorf("ValidateShots() flagged %d shots, want 6", flagged)
+	}
+	for i, shot := range shotData {
+		if !reflect.DeepEqual(shot.Flags, expected[i]) {
+			t.Errorf("Shot %d flags = %q, want %q", i, shot.Flags, expected[i])
+		}
+	}
+
+	// Validating again, as when a processed file is read back in, doesn't repeat flags
+	ValidateShots(shotData)
+	if len(shotData[1].Flags) != 1 {
+		t.Errorf("Expected flags not to repeat, got %q", shotData[1].Flags)
+	}
+}
+
+func TestValidateShotsWithoutSpin(t *testing.T) {
+	// Launch monitors that don't measure spin report zero for every shot
+	shotData := []models.ProcessedShotData{
+		{Club: "7i", Carry: 140, Total: 150, BallSpeed: 112},
+		{Club: "7i", Carry: 142, Total: 151, BallSpeed: 113},
+	}
+	if flagged := ValidateShots(shotData); flagged != 0 {
+		t.Errorf("ValidateShots() flagged %d shots without spin data, want 0", flagged)
+	}
+}
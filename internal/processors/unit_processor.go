@@ -0,0 +1,94 @@
+package processors
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+
+	"albatross/internal/models"
+)
+
+// numberPattern splits a value into an optional direction prefix, the number and an optional
+// suffix, so a directional value such as "5.2 L" can be scaled without losing its direction
+var numberPattern = regexp.MustCompile(`^([LRlr]?\s*)([+-]?(?:\d+\.?\d*|\.\d+))(\s*[LRlr]?)$`)
+
+// ScaleValue multiplies the number in an exported value by factor, keeping any "L" or "R"
+// direction marker, and rounds it to hundredths. It reports false for values that hold no number.
+func ScaleValue(value string, factor float64) (string, bool) {
+	match := numberPattern.FindStringSubmatch(value)
+	if match == nil {
+		return value, false
+	}
+	number, err := strconv.ParseFloat(match[2], 64)
+	if err != nil {
+		return value, false
+	}
+	scaled := strconv.FormatFloat(roundHundredths(number*factor), 'f', -1, 64)
+	return match[1] + scaled + match[3], true
+}
+
+// ConvertUnits converts processed shot data from the yards, feet of apex and mph readers
+// produce into a unit system's distance and speed units, rounding converted values to
+// hundredths. Apex heights stay in feet in the imperial system.
+func ConvertUnits(shotData []models.ProcessedShotData, system string) error {
+	distanceUnit, speedUnit, ok := models.SystemUnits(system)
+	if !ok {
+		return fmt.Errorf("unknown unit system '%s'", system)
+	}
+	if distanceUnit == models.Yards && speedUnit == models.MilesPerHour {
+		return nil
+	}
+
+	distance := func(yards float64) float64 { return roundHundredths(distanceUnit.FromYards(yards)) }
+	height := func(feet float64) float64 { return roundHundredths(distanceUnit.FromYards(models.Feet.ToYards(feet))) }
+	speed := func(mph float64) float64 { return roundHundredths(speedUnit.FromMph(mph)) }
+	for i := range shotData {
+		shot := &shotData[i]
+		shot.Apex = height(shot.Apex)
+		shot.Carry = distance(shot.Carry)
+		shot.Roll = distance(shot.Roll)
+		shot.Target = distance(shot.Target)
+		shot.Total = distance(shot.Total)
+		shot.Side = distance(shot.Side)
+		shot.BallSpeed = speed(shot.BallSpeed)
+		shot.ClubSpeed = speed(shot.ClubSpeed)
+	}
+	return nil
+}
+
+// ConvertUnitsToImperial converts processed shot data read in a unit system's distance and
+// speed units into the yards, feet of apex and mph readers produce, for exports that don't
+// give their units but are known to be in the system, rounding converted values to hundredths
+func ConvertUnitsToImperial(shotData []models.ProcessedShotData, system string) error {
+	distanceUnit, speedUnit, ok := models.SystemUnits(system)
+	if !ok {
+		return fmt.Errorf("unknown unit system '%s'", system)
+	}
+	if distanceUnit == models.Yards && speedUnit == models.MilesPerHour {
+		return nil
+	}
+
+	distance := func(value float64) float64 { return roundHundredths(distanceUnit.ToYards(value)) }
+	height := func(value float64) float64 {
+		return roundHundredths(models.Feet.FromYards(distanceUnit.ToYards(value)))
+	}
+	speed := func(value float64) float64 { return roundHundredths(speedUnit.ToMph(value)) }
+	for i := range shotData {
+		shot := &shotData[i]
+		shot.Apex = height(shot.Apex)
+		shot.Carry = distance(shot.Carry)
+		shot.Roll = distance(shot.Roll)
+		shot.Target = distance(shot.Target)
+		shot.Total = distance(shot.Total)
+		shot.Side = distance(shot.Side)
+		shot.BallSpeed = speed(shot.BallSpeed)
+		shot.ClubSpeed = speed(shot.ClubSpeed)
+	}
+	return nil
+}
+
+// roundHundredths rounds a converted value to two decimal places, the precision launch monitors export
+func roundHundredths(value float64) float64 {
+	return math.Round(value*100) / 100
+}
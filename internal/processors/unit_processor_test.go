@@ -0,0 +1,98 @@
+package processors
+
+import (
+	"reflect"
+	"testing"
+
+	"albatross/internal/models"
+)
+
+func TestScaleValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		factor   float64
+		expected string
+		ok       bool
+	}{
+		{"Number", "141.2", 1.0936133, "154.42", true},
+		{"Negative", "-3", 2, "-6", true},
+		{"Direction suffix", "5.2 L", 2, "10.4 L", true},
+		{"Direction prefix", "R3", 2, "R6", true},
+		{"Dash", "-", 2, "-", false},
+		{"Empty", "", 2, "", false},
+		{"Text", "sideways", 2, "sideways", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, ok := ScaleValue(tt.input, tt.factor)
+			if result != tt.expected || ok != tt.ok {
+				t.Errorf("ScaleValue(%q) = %q, %v, want %q, %v", tt.input, result, ok, tt.expected, tt.ok)
+			}
+		})
+	}
+}
+
+func TestConvertUnits(t *testing.T) {
+	// The apex of the sample MLM2Pro export's first Pw shot, in feet
+	shot := models.ProcessedShotData{
+		Apex: 47.4, Carry: 150, Club: "7i", Roll: 10, Type: "Approach", Target: 155, Total: 160, Side: -5.5,
+		BallSpeed: 120, LaunchAngle: 16.3, SpinRate: 7000, ClubSpeed: 87, SmashFactor: 1.38,
+	}
+
+	imperial := []models.ProcessedShotData{shot}
+	if err := ConvertUnits(imperial, models.UnitsImperial); err != nil {
+		t.Fatalf("ConvertUnits failed: %v", err)
+	}
+	if !reflect.DeepEqual(imperial[0], shot) {
+		t.Errorf("ConvertUnits(imperial) = %+v, want %+v", imperial[0], shot)
+	}
+
+	metric := []models.ProcessedShotData{shot}
+	if err := ConvertUnits(metric, models.UnitsMetric); err != nil {
+		t.Fatalf("ConvertUnits failed: %v", err)
+	}
+	expected := models.ProcessedShotData{
+		Apex: 14.45, Carry: 137.16, Club: "7i", Roll: 9.14, Type: "Approach", Target: 141.73, Total: 146.3, Side: -5.03,
+		BallSpeed: 193.12, LaunchAngle: 16.3, SpinRate: 7000, ClubSpeed: 140.01, SmashFactor: 1.38,
+	}
+	if !reflect.DeepEqual(metric[0], expected) {
+		t.Errorf("ConvertUnits(metric) = %+v, want %+v", metric[0], expected)
+	}
+
+	if err := ConvertUnits(metric, "nautical"); err == nil {
+		t.Errorf("Expected error for unknown unit system, got nil")
+	}
+}
+
+func TestConvertUnitsToImperial(t *testing.T) {
+	shot := models.ProcessedShotData{
+		Apex: 14.45, Carry: 137.16, Club: "7i", Roll: 9.14, Type: "Approach", Total: 146.3, Side: -5.03,
+		BallSpeed: 193.12, LaunchAngle: 16.3, SpinRate: 7000, ClubSpeed: 140.01, SmashFactor: 1.38,
+	}
+
+	imperial := []models.ProcessedShotData{shot}
+	if err := ConvertUnitsToImperial(imperial, models.UnitsImperial); err != nil {
+		t.Fatalf("ConvertUnitsToImperial failed: %v", err)
+	}
+	if !reflect.DeepEqual(imperial[0], shot) {
+		t.Errorf("ConvertUnitsToImperial(imperial) = %+v, want %+v", imperial[0], shot)
+	}
+
+	metric := []models.ProcessedShotData{shot}
+	if err := ConvertUnitsToImperial(metric, models.UnitsMetric); err != nil {
+		t.Fatalf("ConvertUnitsToImperial failed: %v", err)
+	}
+	expected := models.ProcessedShotData{
+		Apex: 47.41, Carry: 150, Club: "7i", Roll: 10, Type: "Approach", Total: 160, Side: -5.5,
+		BallSpeed: 120, LaunchAngle: 16.3, SpinRate: 7000, ClubSpeed: 87, SmashFactor: 1.38,
+	}
+	if !reflect.DeepEqual(metric[0], expected) {
+		t.Errorf("ConvertUnitsToImperial(metric) = %+v, want %+v", metric[0], expected)
+	}
+
+	if err := ConvertUnitsToImperial(metric, "nautical"); err == nil {
+		t.Errorf("Expected error for unknown unit system, got nil")
+	}
+}
@@ -0,0 +1,90 @@
+package reader
+
+import (
+	"albatross/internal/models"
+	"albatross/internal/processors"
+)
+
+// flightScopeDirectionalColumns lists the FS Golf columns that encode direction
+// as a value followed by "L" or "R" (e.g. "5.2 L") rather than a signed number
+var flightScopeDirectionalColumns = []string{"lateral (yds)", "launch h", "spin axis"}
+
+// flightScopeLaunchColumns names the FS Golf export's launch data columns
+var flightScopeLaunchColumns = launchColumns{
+	BallSpeed:       "ball (mph)",
+	LaunchAngle:     "launch v",
+	LaunchDirection: "launch h",
+	DescentAngle:    "descent v",
+	SpinRate:        "spin (rpm)",
+	SpinAxis:        "spin axis",
+	ClubSpeed:       "club (mph)",
+	SmashFactor:     "smash",
+}
+
+// FlightScopeLaunchMonitor implements the LaunchMonitor interface for FlightScope
+// Mevo+ session exports from the FS Golf app
+type FlightScopeLaunchMonitor struct{}
+
+// NewFlightScopeLaunchMonitor creates and returns a new FlightScopeLaunchMonitor instance
+func NewFlightScopeLaunchMonitor() models.LaunchMonitor {
+	return &FlightScopeLaunchMonitor{}
+}
+
+func init() {
+	Register(Registration{
+		Name:        "flightscope",
+		Aliases:     []string{"mevo", "mevo+", "fsgolf"},
+		Description: "FlightScope Mevo+ session CSV export from the FS Golf app",
+		Signature: Signature{
+			Columns: []string{"club", "carry (yds)", "total (yds)", "lateral (yds)", "launch h", "launch v"},
+			Units:   UnitsFromHeaders,
+		},
+		New: NewFlightScopeLaunchMonitor,
+	})
+}
+
+// ParseRow converts a row of strings into a RawShotData struct for FlightScope data.
+// Directional values are rewritten as signed numbers, left negative and right positive,
+// and a row is rejected if one of them cannot be read.
+func (launchMonitor FlightScopeLaunchMonitor) ParseRow(row []string, headers []string) (models.RawShotData, error) {
+	data, err := mapRow(row, headers)
+	if err != nil {
+		return models.RawShotData{}, err
+	}
+
+	if err := signDirectionalColumns(data, flightScopeDirectionalColumns); err != nil {
+		return models.RawShotData{}, err
+	}
+
+	return models.RawShotData{
+		LaunchMonitorType: "FlightScope",
+		Data:              data,
+	}, nil
+}
+
+// ProcessRawData converts RawShotData into ProcessedShotData for FlightScope data
+func (launchMonitor FlightScopeLaunchMonitor) ProcessRawData(rawData models.RawShotData) (models.ProcessedShotData, error) {
+	fields := shotFields{data: rawData.Data}
+	clubType := fields.text("club")
+	carryDistance := fields.optional("carry (yds)")
+	totalDistance := fields.number("total (yds)")
+	sideCarry := fields.number("lateral (yds)")
+
+	normalizedClub := processors.NormalizeClubType(clubType)
+	shotType := processors.DetermineShotType(normalizedClub)
+
+	processed := models.ProcessedShotData{
+		Carry: carryDistance,
+		Club:  normalizedClub,
+		Roll:  rollDistance(totalDistance, carryDistance),
+		Type:  shotType,
+		Total: totalDistance,
+		Side:  sideCarry,
+	}
+	readLaunchData(&processed, &fields, flightScopeLaunchColumns)
+
+	if fields.err != nil {
+		return models.ProcessedShotData{}, fields.err
+	}
+	return processed, nil
+}
@@ -0,0 +1,90 @@
+package reader
+
+import (
+	"reflect"
+	"testing"
+
+	"albatross/internal/models"
+)
+
+func TestFlightScopeLaunchMonitorParseRow(t *testing.T) {
+	launchMonitor := FlightScopeLaunchMonitor{}
+	headers := []string{"club", "carry (yds)", "total (yds)", "lateral (yds)", "launch h"}
+
+	tests := []struct {
+		name     string
+		row      []string
+		expected map[string]string
+		wantErr  bool
+	}{
+		{
+			name: "Left and right suffixes",
+			row:  []string{"7 Iron", "141.2", "150.8", "5.2 L", "1.4 R"},
+			expected: map[string]string{
+				"club":          "7 Iron",
+				"carry (yds)":   "141.2",
+				"total (yds)":   "150.8",
+				"lateral (yds)": "-5.2",
+				"launch h":      "1.4",
+			},
+		},
+		{
+			name: "Blank direction is left untouched",
+			row:  []string{"Driver", "230.0", "251.1", "", "0.5 L"},
+			expected: map[string]string{
+				"club":          "Driver",
+				"carry (yds)":   "230.0",
+				"total (yds)":   "251.1",
+				"lateral (yds)": "",
+				"launch h":      "-0.5",
+			},
+		},
+		{
+			name:    "Unreadable direction",
+			row:     []string{"Driver", "230.0", "251.1", "left", "0.5 L"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := launchMonitor.ParseRow(tt.row, headers)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRow() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(result.Data, tt.expected) {
+				t.Errorf("ParseRow() = %v, want %v", result.Data, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFlightScopeLaunchMonitorProcessRawData(t *testing.T) {
+	launchMonitor := FlightScopeLaunchMonitor{}
+	rawData := models.RawShotData{
+		LaunchMonitorType: "FlightScope",
+		Data: map[string]string{
+			"club":          "3 Wood",
+			"total (yds)":   "215.4",
+			"lateral (yds)": "-5.2",
+		},
+	}
+
+	expected := models.ProcessedShotData{
+		Club:  "3W",
+		Type:  "Tee",
+		Total: 215.4,
+		Side:  -5.2,
+	}
+
+	result, err := launchMonitor.ProcessRawData(rawData)
+	if err != nil {
+		t.Fatalf("ProcessRawData failed: %v", err)
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("ProcessRawData() = %v, want %v", result, expected)
+	}
+}
@@ -0,0 +1,100 @@
+package reader
+
+import (
+	"albatross/internal/models"
+	"albatross/internal/processors"
+)
+
+// foresightDirectionalColumns lists the FSX columns that may encode direction
+// with an "L" or "R" marker instead of a sign
+var foresightDirectionalColumns = []string{"offline", "azimuth", "spin axis", "club path", "face angle"}
+
+// foresightLaunchColumns names the FSX export's launch data columns. FSX reports the
+// horizontal launch angle as "Azimuth".
+var foresightLaunchColumns = launchColumns{
+	BallSpeed:       "ball speed",
+	LaunchAngle:     "launch angle",
+	LaunchDirection: "azimuth",
+	DescentAngle:    "descent angle",
+	SpinRate:        "total spin",
+	SpinAxis:        "spin axis",
+	Backspin:        "back spin",
+	Sidespin:        "side spin",
+	ClubSpeed:       "club speed",
+	SmashFactor:     "smash factor",
+}
+
+// ForesightLaunchMonitor implements the LaunchMonitor interface for Foresight Sports
+// FSX exports from the GC3 and GCQuad launch monitors
+type ForesightLaunchMonitor struct{}
+
+// NewForesightLaunchMonitor creates and returns a new ForesightLaunchMonitor instance
+func NewForesightLaunchMonitor() models.LaunchMonitor {
+	return &ForesightLaunchMonitor{}
+}
+
+func init() {
+	Register(Registration{
+		Name:        "foresight",
+		Aliases:     []string{"gcquad", "gc3", "fsx"},
+		Description: "Foresight Sports GC3/GCQuad session CSV export from FSX, including club data",
+		Signature: Signature{
+			Columns: []string{"club", "carry", "total", "offline", "azimuth", "club path", "face angle", "angle of attack", "dynamic loft"},
+			Units:   UnitsAssumed,
+		},
+		New: NewForesightLaunchMonitor,
+	})
+}
+
+// ParseRow converts a row of strings into a RawShotData struct for Foresight data
+func (launchMonitor ForesightLaunchMonitor) ParseRow(row []string, headers []string) (models.RawShotData, error) {
+	data, err := mapRow(row, headers)
+	if err != nil {
+		return models.RawShotData{}, err
+	}
+
+	if err := signDirectionalColumns(data, foresightDirectionalColumns); err != nil {
+		return models.RawShotData{}, err
+	}
+
+	return models.RawShotData{
+		LaunchMonitorType: "Foresight",
+		Data:              data,
+	}, nil
+}
+
+// ProcessRawData converts RawShotData into ProcessedShotData for Foresight data,
+// including the club delivery columns measured by the GCQuad's club markers
+func (launchMonitor ForesightLaunchMonitor) ProcessRawData(rawData models.RawShotData) (models.ProcessedShotData, error) {
+	fields := shotFields{data: rawData.Data}
+	clubType := fields.text("club")
+	carryDistance := fields.optional("carry")
+	totalDistance := fields.number("total")
+	sideCarry := fields.number("offline")
+	clubPath := fields.optional("club path")
+	faceAngle := fields.optional("face angle")
+	attackAngle := fields.optional("angle of attack")
+	dynamicLoft := fields.optional("dynamic loft")
+
+	normalizedClub := processors.NormalizeClubType(clubType)
+	shotType := processors.DetermineShotType(normalizedClub)
+
+	processed := models.ProcessedShotData{
+		Carry:       carryDistance,
+		Club:        normalizedClub,
+		Roll:        rollDistance(totalDistance, carryDistance),
+		Type:        shotType,
+		Total:       totalDistance,
+		Side:        sideCarry,
+		ClubPath:    clubPath,
+		FaceAngle:   faceAngle,
+		AttackAngle: attackAngle,
+		DynamicLoft: dynamicLoft,
+	}
+	readLaunchData(&processed, &fields, foresightLaunchColumns)
+
+	if fields.err != nil {
+		return models.ProcessedShotData{}, fields.err
+	}
+	return processed, nil
+}
@@ -0,0 +1,69 @@
+package reader
+
+import (
+	"reflect"
+	"testing"
+
+	"albatross/internal/models"
+)
+
+func TestForesightLaunchMonitorParseRow(t *testing.T) {
+	launchMonitor := ForesightLaunchMonitor{}
+	headers := []string{"club", "total", "offline", "club path", "face angle", "angle of attack"}
+
+	result, err := launchMonitor.ParseRow([]string{"Driver", "262.4", "8.1 R", "2.3 L", "-1.1", "3.2"}, headers)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := map[string]string{
+		"club":            "Driver",
+		"total":           "262.4",
+		"offline":         "8.1",
+		"club path":       "-2.3",
+		"face angle":      "-1.1",
+		"angle of attack": "3.2",
+	}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Errorf("ParseRow() = %v, want %v", result.Data, expected)
+	}
+
+	if _, err := launchMonitor.ParseRow([]string{"Driver", "262.4", "8.1 R", "n/a", "-1.1", "3.2"}, headers); err == nil {
+		t.Errorf("Expected error for unreadable club path, got nil")
+	}
+}
+
+func TestForesightLaunchMonitorProcessRawData(t *testing.T) {
+	launchMonitor := ForesightLaunchMonitor{}
+	rawData := models.RawShotData{
+		LaunchMonitorType: "Foresight",
+		Data: map[string]string{
+			"club":            "6 Iron",
+			"total":           "172.5",
+			"offline":         "-3.4",
+			"club path":       "1.8",
+			"face angle":      "-0.6",
+			"angle of attack": "-4.1",
+			"dynamic loft":    "21.7",
+		},
+	}
+
+	expected := models.ProcessedShotData{
+		Club:        "6i",
+		Type:        "Approach",
+		Total:       172.5,
+		Side:        -3.4,
+		ClubPath:    1.8,
+		FaceAngle:   -0.6,
+		AttackAngle: -4.1,
+		DynamicLoft: 21.7,
+	}
+
+	result, err := launchMonitor.ProcessRawData(rawData)
+	if err != nil {
+		t.Fatalf("ProcessRawData failed: %v", err)
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("ProcessRawData() = %v, want %v", result, expected)
+	}
+}
@@ -0,0 +1,96 @@
+package reader
+
+import (
+	"albatross/internal/models"
+	"albatross/internal/processors"
+)
+
+// garminLaunchColumns names the Garmin Golf export's launch data columns. Older exports
+// only report spin as backspin and sidespin components.
+var garminLaunchColumns = launchColumns{
+	BallSpeed:       "ball speed",
+	LaunchAngle:     "launch angle",
+	LaunchDirection: "launch direction",
+	SpinRate:        "spin rate",
+	SpinAxis:        "spin axis",
+	Backspin:        "backspin",
+	Sidespin:        "sidespin",
+	ClubSpeed:       "club speed",
+	SmashFactor:     "smash factor",
+}
+
+// GarminLaunchMonitor implements the LaunchMonitor interface for Garmin Golf app
+// session exports from the Approach R10 and R50 launch monitors
+type GarminLaunchMonitor struct{}
+
+// NewGarminLaunchMonitor creates and returns a new GarminLaunchMonitor instance
+func NewGarminLaunchMonitor() models.LaunchMonitor {
+	return &GarminLaunchMonitor{}
+}
+
+func init() {
+	Register(Registration{
+		Name:        "garmin",
+		Aliases:     []string{"r10", "r50", "approach"},
+		Description: "Garmin Approach R10/R50 session CSV export from the Garmin Golf app",
+		Signature: Signature{
+			Columns: []string{"club name", "club type", "carry distance", "carry deviation distance", "total distance", "total deviation distance", "backspin", "sidespin"},
+			Units:   UnitsFromHeaders,
+			// Garmin's unit row gives apex height in feet or meters rather than the yards of its distances
+			HeightColumns: []string{"apex height"},
+		},
+		New: NewGarminLaunchMonitor,
+	})
+}
+
+// ParseRow converts a row of strings into a RawShotData struct for Garmin data
+func (launchMonitor GarminLaunchMonitor) ParseRow(row []string, headers []string) (models.RawShotData, error) {
+	data, err := mapRow(row, headers)
+	if err != nil {
+		return models.RawShotData{}, err
+	}
+
+	return models.RawShotData{
+		LaunchMonitorType: "Garmin",
+		Data:              data,
+	}, nil
+}
+
+// ProcessRawData converts RawShotData into ProcessedShotData for Garmin data.
+// Garmin reports the lateral landing position as "Carry Deviation Distance",
+// negative to the left and positive to the right, and spells clubs out in full
+// (e.g. "7 Iron", "Pitching Wedge"). The R10 and R50 also estimate club delivery,
+// reported as "Club Path", "Club Face" and "Attack Angle".
+func (launchMonitor GarminLaunchMonitor) ProcessRawData(rawData models.RawShotData) (models.ProcessedShotData, error) {
+	fields := shotFields{data: rawData.Data}
+	clubType := fields.text("club type")
+	carryDistance := fields.optional("carry distance")
+	totalDistance := fields.number("total distance")
+	sideCarry := fields.number("carry deviation distance")
+	apex := fields.optional("apex height")
+	clubPath := fields.optional("club path")
+	faceAngle := fields.optional("club face")
+	attackAngle := fields.optional("attack angle")
+
+	normalizedClub := processors.NormalizeClubType(clubType)
+	shotType := processors.DetermineShotType(normalizedClub)
+
+	processed := models.ProcessedShotData{
+		Apex:        apex,
+		Carry:       carryDistance,
+		Club:        normalizedClub,
+		Roll:        rollDistance(totalDistance, carryDistance),
+		Type:        shotType,
+		Total:       totalDistance,
+		Side:        sideCarry,
+		ClubPath:    clubPath,
+		FaceAngle:   faceAngle,
+		AttackAngle: attackAngle,
+	}
+	readLaunchData(&processed, &fields, garminLaunchColumns)
+
+	if fields.err != nil {
+		return models.ProcessedShotData{}, fields.err
+	}
+	return processed, nil
+}
@@ -0,0 +1,148 @@
+package reader
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"albatross/internal/models"
+)
+
+func TestGarminLaunchMonitorParseRow(t *testing.T) {
+	launchMonitor := GarminLaunchMonitor{}
+	headers := []string{"club type", "total distance", "carry deviation distance"}
+	row := []string{"7 Iron", " 152.3 ", "-4.1"}
+
+	expected := models.RawShotData{
+		LaunchMonitorType: "Garmin",
+		Data: map[string]string{
+			"club type":                "7 Iron",
+			"total distance":           "152.3",
+			"carry deviation distance": "-4.1",
+		},
+	}
+
+	result, err := launchMonitor.ParseRow(row, headers)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("ParseRow() = %v, want %v", result, expected)
+	}
+
+	if _, err := launchMonitor.ParseRow([]string{"7 Iron"}, headers); err == nil {
+		t.Errorf("Expected error for short row, got nil")
+	}
+}
+
+func TestGarminLaunchMonitorProcessRawData(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     map[string]string
+		expected models.ProcessedShotData
+	}{
+		{
+			name: "Driver",
+			data: map[string]string{
+				"club type":                "Driver",
+				"total distance":           "245.6",
+				"carry deviation distance": "12.4",
+			},
+			expected: models.ProcessedShotData{Club: "Dr", Type: "Tee", Total: 245.6, Side: 12.4},
+		},
+		{
+			name: "Pitching wedge",
+			data: map[string]string{
+				"club type":                "Pitching Wedge",
+				"total distance":           "110.2",
+				"carry deviation distance": "-3.0",
+			},
+			expected: models.ProcessedShotData{Club: "Pw", Type: "Approach", Total: 110.2, Side: -3},
+		},
+		{
+			name: "Club delivery and apex",
+			data: map[string]string{
+				"club type":                "7 Iron",
+				"total distance":           "152.3",
+				"carry deviation distance": "-4.1",
+				"apex height":              "82.5",
+				"club path":                "-2.1",
+				"club face":                "1.4",
+				"attack angle":             "-3.8",
+			},
+			expected: models.ProcessedShotData{Apex: 82.5, Club: "7i", Type: "Approach", Total: 152.3, Side: -4.1, ClubPath: -2.1, FaceAngle: 1.4, AttackAngle: -3.8},
+		},
+	}
+
+	launchMonitor := GarminLaunchMonitor{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := launchMonitor.ProcessRawData(models.RawShotData{LaunchMonitorType: "Garmin", Data: tt.data})
+			if err != nil {
+				t.Fatalf("ProcessRawData failed: %v", err)
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("ProcessRawData() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGarminLaunchMonitorProcessRawDataErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     map[string]string
+		expected *models.FieldError // nil when the shot should be read
+	}{
+		{
+			name:     "Blank total",
+			data:     map[string]string{"club type": "Driver", "total distance": "", "carry deviation distance": "12.4"},
+			expected: &models.FieldError{Field: "total distance", Value: "", Reason: "missing value"},
+		},
+		{
+			name:     "Dashed total",
+			data:     map[string]string{"club type": "Driver", "total distance": "-", "carry deviation distance": "12.4"},
+			expected: &models.FieldError{Field: "total distance", Value: "-", Reason: "missing value"},
+		},
+		{
+			name:     "Invalid side",
+			data:     map[string]string{"club type": "Driver", "total distance": "245.6", "carry deviation distance": "wide"},
+			expected: &models.FieldError{Field: "carry deviation distance", Value: "wide", Reason: `invalid number "wide"`},
+		},
+		{
+			name:     "Missing club",
+			data:     map[string]string{"total distance": "245.6", "carry deviation distance": "12.4"},
+			expected: &models.FieldError{Field: "club type", Value: "", Reason: "missing value"},
+		},
+		{
+			name:     "Invalid ball speed",
+			data:     map[string]string{"club type": "Driver", "total distance": "245.6", "carry deviation distance": "12.4", "ball speed": "fast"},
+			expected: &models.FieldError{Field: "ball speed", Value: "fast", Reason: `invalid number "fast"`},
+		},
+		{
+			name: "Dashed ball speed",
+			data: map[string]string{"club type": "Driver", "total distance": "245.6", "carry deviation distance": "12.4", "ball speed": "--"},
+		},
+	}
+
+	launchMonitor := GarminLaunchMonitor{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := launchMonitor.ProcessRawData(models.RawShotData{LaunchMonitorType: "Garmin", Data: tt.data})
+			if tt.expected == nil {
+				if err != nil {
+					t.Errorf("ProcessRawData() unexpected error: %v", err)
+				}
+				return
+			}
+
+			var fieldError *models.FieldError
+			if !errors.As(err, &fieldError) {
+				t.Fatalf("ProcessRawData() error = %v, want %v", err, tt.expected)
+			}
+			if *fieldError != *tt.expected {
+				t.Errorf("ProcessRawData() error = %+v, want %+v", *fieldError, *tt.expected)
+			}
+		})
+	}
+}
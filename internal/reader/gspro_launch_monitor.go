@@ -0,0 +1,182 @@
+package reader
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"albatross/internal/models"
+	"albatross/internal/processors"
+)
+
+// gsproDirectionalColumns lists the GSPro columns that may encode direction
+// with an "L" or "R" marker instead of a sign
+var gsproDirectionalColumns = []string{"offline", "hla", "spin axis"}
+
+// gsproLaunchColumns names the GSPro shot log's launch data columns, which follow the
+// Open Connect API's names: VLA and HLA are the vertical and horizontal launch angles
+var gsproLaunchColumns = launchColumns{
+	BallSpeed:       "ball speed",
+	LaunchAngle:     "vla",
+	LaunchDirection: "hla",
+	SpinRate:        "total spin",
+	SpinAxis:        "spin axis",
+	Backspin:        "back spin",
+	Sidespin:        "side spin",
+	ClubSpeed:       "club speed",
+}
+
+// GSProLaunchMonitor implements the LaunchMonitor and DocumentParser interfaces for
+// GSPro simulator shot logs, which are written as either CSV or JSON
+type GSProLaunchMonitor struct{}
+
+// NewGSProLaunchMonitor creates and returns a new GSProLaunchMonitor instance
+func NewGSProLaunchMonitor() models.LaunchMonitor {
+	return &GSProLaunchMonitor{}
+}
+
+func init() {
+	Register(Registration{
+		Name:        "gspro",
+		Aliases:     []string{"gs-pro"},
+		Description: "GSPro simulator shot log, as CSV or JSON",
+		Signature: Signature{
+			Columns:      []string{"club", "ball speed", "vla", "hla", "spin axis", "total spin", "carry", "offline"},
+			DocumentKeys: []string{"club", "balldata", "carry", "offline"},
+			Units:        UnitsKnown,
+		},
+		New: NewGSProLaunchMonitor,
+	})
+}
+
+// gsproShot mirrors a shot in a GSPro JSON shot log. Ball launch data is nested
+// under BallData as in GSPro's Open Connect API, with the shot's result alongside it.
+type gsproShot struct {
+	Club     string `json:"Club"`
+	BallData struct {
+		Speed     json.Number `json:"Speed"`
+		VLA       json.Number `json:"VLA"`
+		HLA       json.Number `json:"HLA"`
+		SpinAxis  json.Number `json:"SpinAxis"`
+		TotalSpin json.Number `json:"TotalSpin"`
+	} `json:"BallData"`
+	ClubData struct {
+		Speed json.Number `json:"Speed"`
+	} `json:"ClubData"`
+	Carry   json.Number `json:"Carry"`
+	Total   json.Number `json:"Total"`
+	Offline json.Number `json:"Offline"`
+}
+
+// gsproLog is a JSON object that is either a single shot or a wrapper holding a list of shots
+type gsproLog struct {
+	gsproShot
+	Shots []gsproShot `json:"Shots"`
+}
+
+// ParseDocument decodes a GSPro JSON shot log. The log may be an array of shots,
+// an object with a "Shots" array, or newline-delimited shot objects.
+func (launchMonitor GSProLaunchMonitor) ParseDocument(r io.Reader) ([]models.RawShotData, error) {
+	decoder := json.NewDecoder(r)
+
+	var shots []gsproShot
+	for {
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("decoding GSPro shot log: %w", err)
+		}
+
+		if bytes.HasPrefix(bytes.TrimSpace(value), []byte("[")) {
+			var list []gsproShot
+			if err := json.Unmarshal(value, &list); err != nil {
+				return nil, fmt.Errorf("decoding GSPro shot log: %w", err)
+			}
+			shots = append(shots, list...)
+			continue
+		}
+
+		var log gsproLog
+		if err := json.Unmarshal(value, &log); err != nil {
+			return nil, fmt.Errorf("decoding GSPro shot log: %w", err)
+		}
+		if log.Shots != nil {
+			shots = append(shots, log.Shots...)
+		} else {
+			shots = append(shots, log.gsproShot)
+		}
+	}
+
+	rawShots := make([]models.RawShotData, 0, len(shots))
+	for _, shot := range shots {
+		rawShots = append(rawShots, models.RawShotData{
+			LaunchMonitorType: "gspro",
+			Data: map[string]string{
+				"club":       shot.Club,
+				"ball speed": shot.BallData.Speed.String(),
+				"vla":        shot.BallData.VLA.String(),
+				"hla":        shot.BallData.HLA.String(),
+				"spin axis":  shot.BallData.SpinAxis.String(),
+				"total spin": shot.BallData.TotalSpin.String(),
+				"club speed": shot.ClubData.Speed.String(),
+				"carry":      shot.Carry.String(),
+				"total":      shot.Total.String(),
+				"offline":    shot.Offline.String(),
+			},
+		})
+	}
+	return rawShots, nil
+}
+
+// ParseRow converts a row of strings into a RawShotData struct for GSPro CSV shot logs
+func (launchMonitor GSProLaunchMonitor) ParseRow(row []string, headers []string) (models.RawShotData, error) {
+	data, err := mapRow(row, headers)
+	if err != nil {
+		return models.RawShotData{}, err
+	}
+
+	if err := signDirectionalColumns(data, gsproDirectionalColumns); err != nil {
+		return models.RawShotData{}, err
+	}
+
+	return models.RawShotData{
+		LaunchMonitorType: "gspro",
+		Data:              data,
+	}, nil
+}
+
+// ProcessRawData converts RawShotData into ProcessedShotData for GSPro data.
+// Shot logs without a total distance use the carry distance as the total.
+func (launchMonitor GSProLaunchMonitor) ProcessRawData(rawData models.RawShotData) (models.ProcessedShotData, error) {
+	fields := shotFields{data: rawData.Data}
+	clubType := fields.text("club")
+	var carryDistance, totalDistance float64
+	if fields.has("total") {
+		carryDistance = fields.optional("carry")
+		totalDistance = fields.number("total")
+	} else {
+		carryDistance = fields.number("carry")
+		totalDistance = carryDistance
+	}
+	sideCarry := fields.number("offline")
+
+	normalizedClub := processors.NormalizeClubType(clubType)
+	shotType := processors.DetermineShotType(normalizedClub)
+
+	processed := models.ProcessedShotData{
+		Carry: carryDistance,
+		Club:  normalizedClub,
+		Roll:  rollDistance(totalDistance, carryDistance),
+		Type:  shotType,
+		Total: totalDistance,
+		Side:  sideCarry,
+	}
+	readLaunchData(&processed, &fields, gsproLaunchColumns)
+
+	if fields.err != nil {
+		return models.ProcessedShotData{}, fields.err
+	}
+	return processed, nil
+}
@@ -0,0 +1,120 @@
+package reader
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"albatross/internal/models"
+)
+
+func TestGSProLaunchMonitorParseDocument(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		clubs []string
+	}{
+		{
+			name:  "Array of shots",
+			input: `[{"Club": "7I", "BallData": {"Speed": 121.3, "VLA": 17.8}, "Carry": 158.2, "Offline": -4.1}, {"Club": "DR", "Carry": 245.0}]`,
+			clubs: []string{"7I", "DR"},
+		},
+		{
+			name:  "Wrapped shots",
+			input: `{"Player": "Palmer", "Shots": [{"Club": "PW", "Carry": 101.5}]}`,
+			clubs: []string{"PW"},
+		},
+		{
+			name:  "Newline-delimited shots",
+			input: "{\"Club\": \"4H\", \"Carry\": 190.1}\n{\"Club\": \"5I\", \"Carry\": 175.4}\n",
+			clubs: []string{"4H", "5I"},
+		},
+	}
+
+	launchMonitor := GSProLaunchMonitor{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rawShots, err := launchMonitor.ParseDocument(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("ParseDocument failed: %v", err)
+			}
+
+			var clubs []string
+			for _, rawShot := range rawShots {
+				if rawShot.LaunchMonitorType != "gspro" {
+					t.Errorf("Expected LaunchMonitorType to be 'gspro', got %s", rawShot.LaunchMonitorType)
+				}
+				clubs = append(clubs, rawShot.Data["club"])
+			}
+			if !reflect.DeepEqual(clubs, tt.clubs) {
+				t.Errorf("Parsed clubs %v, want %v", clubs, tt.clubs)
+			}
+		})
+	}
+
+	rawShots, _ := launchMonitor.ParseDocument(strings.NewReader(tests[0].input))
+	first := rawShots[0].Data
+	if first["ball speed"] != "121.3" || first["vla"] != "17.8" || first["carry"] != "158.2" || first["offline"] != "-4.1" {
+		t.Errorf("Unexpected data for first shot: %v", first)
+	}
+
+	if _, err := launchMonitor.ParseDocument(strings.NewReader(`[{"Club": 7}]`)); err == nil {
+		t.Errorf("Expected error for invalid shot log, got nil")
+	}
+}
+
+func TestGSProLaunchMonitorParseRow(t *testing.T) {
+	launchMonitor := GSProLaunchMonitor{}
+	headers := []string{"club", "ball speed", "hla", "carry", "offline"}
+
+	result, err := launchMonitor.ParseRow([]string{"7I", "121.3", "1.2 L", "158.2", "4.1 L"}, headers)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := models.RawShotData{
+		LaunchMonitorType: "gspro",
+		Data: map[string]string{
+			"club":       "7I",
+			"ball speed": "121.3",
+			"hla":        "-1.2",
+			"carry":      "158.2",
+			"offline":    "-4.1",
+		},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("ParseRow() = %v, want %v", result, expected)
+	}
+}
+
+func TestGSProLaunchMonitorProcessRawData(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     map[string]string
+		expected models.ProcessedShotData
+	}{
+		{
+			name:     "Total distance",
+			data:     map[string]string{"club": "DR", "carry": "245.0", "total": "268.4", "offline": "12.0"},
+			expected: models.ProcessedShotData{Carry: 245, Club: "Dr", Roll: 23.4, Type: "Tee", Total: 268.4, Side: 12},
+		},
+		{
+			name:     "Carry only",
+			data:     map[string]string{"club": "7I", "carry": "158.2", "total": "", "offline": "-4.1"},
+			expected: models.ProcessedShotData{Carry: 158.2, Club: "7i", Type: "Approach", Total: 158.2, Side: -4.1},
+		},
+	}
+
+	launchMonitor := GSProLaunchMonitor{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := launchMonitor.ProcessRawData(models.RawShotData{LaunchMonitorType: "gspro", Data: tt.data})
+			if err != nil {
+				t.Fatalf("ProcessRawData failed: %v", err)
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("ProcessRawData() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
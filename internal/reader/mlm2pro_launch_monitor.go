@@ -1,15 +1,36 @@
 package reader
 
 import (
-	"fmt"
-	"strconv"
+	"regexp"
 	"strings"
+	"time"
 
 	"albatross/internal/models"
 	"albatross/internal/processors"
 )
 
-// MLM2ProLaunchMonitor implements the LaunchMonitor interface for the MLM2Pro launch monitor
+// mlm2ProLaunchColumns names the MLM2PRO export's launch data columns
+var mlm2ProLaunchColumns = launchColumns{
+	BallSpeed:       "ball speed",
+	LaunchAngle:     "launch angle",
+	LaunchDirection: "launch direction",
+	DescentAngle:    "descent angle",
+	SpinRate:        "spin rate",
+	SpinAxis:        "spin axis",
+	ClubSpeed:       "club speed",
+	SmashFactor:     "smash factor",
+	ClubBrand:       "club brand",
+	ClubModel:       "club model",
+}
+
+// mlm2ProTitle matches the title row of an MLM2PRO export, capturing the device, the player
+// and the session's start time, e.g. "Rapsodo MLM2PRO: Palmer Little - 09/05/2024 9:27 PM"
+var mlm2ProTitle = regexp.MustCompile(`^\s*(Rapsodo MLM2PRO)\s*:\s*(.*?)\s*-\s*(\d{1,2}/\d{1,2}/\d{4}\s+\d{1,2}:\d{2}\s*[AaPp][Mm])\s*$`)
+
+// mlm2ProTimeLayout is the layout of the start time in an MLM2PRO title row
+const mlm2ProTimeLayout = "1/2/2006 3:04 PM"
+
+// MLM2ProLaunchMonitor implements the LaunchMonitor and SessionParser interfaces for the MLM2Pro launch monitor
 type MLM2ProLaunchMonitor struct{}
 
 // NewMLM2ProLaunchMonitor creates and returns a new MLM2ProLaunchMonitor instance
@@ -17,15 +38,46 @@ func NewMLM2ProLaunchMonitor() models.LaunchMonitor {
 	return &MLM2ProLaunchMonitor{}
 }
 
-// ParseRow converts a row of strings into a RawShotData struct for MLM2Pro data
-func (launchMonitor MLM2ProLaunchMonitor) ParseRow(row []string, headers []string) (models.RawShotData, error) {
-	if len(row) < len(headers) {
-		return models.RawShotData{}, fmt.Errorf("insufficient columns")
+func init() {
+	Register(Registration{
+		Name:        "mlm2pro",
+		Aliases:     []string{"rapsodo", "mlm2"},
+		Description: "Rapsodo MLM2PRO session CSV export",
+		Signature: Signature{
+			Title:   regexp.MustCompile(`(?i)^rapsodo mlm2pro:`),
+			Columns: []string{"club type", "club brand", "club model", "carry distance", "total distance", "side carry", "spin axis"},
+			Units:   UnitsAssumed,
+		},
+		New: NewMLM2ProLaunchMonitor,
+	})
+}
+
+// ParseSessionRow reads the device, player and start time from an MLM2PRO title row.
+// The export doesn't record a time zone, so the start time is the wall clock time it shows
+// in the local time zone, where the export was most likely made.
+func (launchMonitor MLM2ProLaunchMonitor) ParseSessionRow(row []string, session *models.Session) bool {
+	if len(row) == 0 {
+		return false
+	}
+	match := mlm2ProTitle.FindStringSubmatch(row[0])
+	if match == nil {
+		return false
 	}
 
-	data := make(map[string]string)
-	for i, header := range headers {
-		data[header] = strings.TrimSpace(row[i])
+	session.Device = match[1]
+	session.Player = match[2]
+	startedAt := strings.ToUpper(strings.Join(strings.Fields(match[3]), " "))
+	if startTime, err := time.ParseInLocation(mlm2ProTimeLayout, startedAt, time.Local); err == nil {
+		session.StartTime = &startTime
+	}
+	return true
+}
+
+// ParseRow converts a row of strings into a RawShotData struct for MLM2Pro data
+func (launchMonitor MLM2ProLaunchMonitor) ParseRow(row []string, headers []string) (models.RawShotData, error) {
+	data, err := mapRow(row, headers)
+	if err != nil {
+		return models.RawShotData{}, err
 	}
 
 	return models.RawShotData{
@@ -35,20 +87,30 @@ func (launchMonitor MLM2ProLaunchMonitor) ParseRow(row []string, headers []strin
 }
 
 // ProcessRawData converts RawShotData into ProcessedShotData for MLM2Pro data
-func (launchMonitor MLM2ProLaunchMonitor) ProcessRawData(rawData models.RawShotData) models.ProcessedShotData {
-	clubType := rawData.Data["club type"]
-	totalDistance, _ := strconv.ParseFloat(rawData.Data["total distance"], 64)
-	sideCarry, _ := strconv.ParseFloat(rawData.Data["side carry"], 64)
+func (launchMonitor MLM2ProLaunchMonitor) ProcessRawData(rawData models.RawShotData) (models.ProcessedShotData, error) {
+	fields := shotFields{data: rawData.Data}
+	clubType := fields.text("club type")
+	carryDistance := fields.optional("carry distance")
+	totalDistance := fields.number("total distance")
+	sideCarry := fields.number("side carry")
+	apex := fields.optional("apex")
 
 	normalizedClub := processors.NormalizeClubType(clubType)
 	shotType := processors.DetermineShotType(normalizedClub)
 
 	processed := models.ProcessedShotData{
+		Apex:  apex,
+		Carry: carryDistance,
 		Club:  normalizedClub,
+		Roll:  rollDistance(totalDistance, carryDistance),
 		Type:  shotType,
 		Total: totalDistance,
 		Side:  sideCarry,
 	}
+	readLaunchData(&processed, &fields, mlm2ProLaunchColumns)
 
-	return processed
+	if fields.err != nil {
+		return models.ProcessedShotData{}, fields.err
+	}
+	return processed, nil
 }
@@ -3,6 +3,7 @@ package reader
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"albatross/internal/models"
 )
@@ -32,24 +33,154 @@ func TestMLM2ProLaunchMonitorParseRow(t *testing.T) {
 
 func TestMLM2ProLaunchMonitorProcessRawData(t *testing.T) {
 	launchMonitor := MLM2ProLaunchMonitor{}
-	rawData := models.RawShotData{
-		LaunchMonitorType: "MLM2Pro",
-		Data: map[string]string{
-			"club type":      "Dr",
-			"total distance": "250",
-			"side carry":     "10",
+	tests := []struct {
+		name     string
+		data     map[string]string
+		expected models.ProcessedShotData
+	}{
+		{
+			name: "Total and side only",
+			data: map[string]string{
+				"club type":      "Dr",
+				"total distance": "250",
+				"side carry":     "10",
+			},
+			expected: models.ProcessedShotData{
+				Club:  "Dr",
+				Type:  "Tee",
+				Total: 250,
+				Side:  10,
+			},
+		},
+		{
+			name: "Full export row",
+			data: map[string]string{
+				"club type":        "Dr",
+				"club brand":       "TaylorMade",
+				"club model":       "Qi10",
+				"carry distance":   "231.5",
+				"total distance":   "254.0",
+				"ball speed":       "148.2",
+				"launch angle":     "12.8",
+				"launch direction": "-1.4",
+				"apex":             "31.0",
+				"side carry":       "-9.3",
+				"club speed":       "101.1",
+				"smash factor":     "1.47",
+				"descent angle":    "36.2",
+				"spin rate":        "2611",
+				"spin axis":        "-4.2",
+			},
+			expected: models.ProcessedShotData{
+				Apex:            31,
+				Carry:           231.5,
+				Club:            "Dr",
+				Roll:            22.5,
+				Type:            "Tee",
+				Total:           254,
+				Side:            -9.3,
+				BallSpeed:       148.2,
+				LaunchAngle:     12.8,
+				LaunchDirection: -1.4,
+				DescentAngle:    36.2,
+				SpinRate:        2611,
+				SpinAxis:        -4.2,
+				ClubSpeed:       101.1,
+				SmashFactor:     1.47,
+				ClubBrand:       "TaylorMade",
+				ClubModel:       "Qi10",
+			},
+		},
+		{
+			name: "Carry and apex",
+			data: map[string]string{
+				"club type":      "7i",
+				"carry distance": "141.5",
+				"total distance": "150",
+				"apex":           "28.4",
+				"side carry":     "-3.2",
+			},
+			expected: models.ProcessedShotData{
+				Apex:  28.4,
+				Carry: 141.5,
+				Club:  "7i",
+				Roll:  8.5,
+				Type:  "Approach",
+				Total: 150,
+				Side:  -3.2,
+			},
 		},
 	}
 
-	expected := models.ProcessedShotData{
-		Club:  "Dr",
-		Type:  "Tee",
-		Total: 250,
-		Side:  10,
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rawData := models.RawShotData{LaunchMonitorType: "MLM2Pro", Data: tt.data}
+			result, err := launchMonitor.ProcessRawData(rawData)
+			if err != nil {
+				t.Fatalf("ProcessRawData failed: %v", err)
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("ProcessRawData() = %v, want %v", result, tt.expected)
+			}
+		})
 	}
+}
 
-	result := launchMonitor.ProcessRawData(rawData)
-	if !reflect.DeepEqual(result, expected) {
-		t.Errorf("ProcessRawData() = %v, want %v", result, expected)
+func TestMLM2ProLaunchMonitorParseSessionRow(t *testing.T) {
+	launchMonitor := MLM2ProLaunchMonitor{}
+	tests := []struct {
+		name      string
+		row       []string
+		expected  models.Session
+		wantMatch bool
+	}{
+		{
+			name: "Title row",
+			row:  []string{"Rapsodo MLM2PRO: Palmer Little - 09/05/2024 9:27 PM", "", ""},
+			expected: models.Session{
+				Device:    "Rapsodo MLM2PRO",
+				Player:    "Palmer Little",
+				StartTime: timePointer(time.Date(2024, 9, 5, 21, 27, 0, 0, time.Local)),
+			},
+			wantMatch: true,
+		},
+		{
+			name: "Unpadded date and lowercase meridiem",
+			row:  []string{"Rapsodo MLM2PRO: Sam - 3/7/2025 10:05 am"},
+			expected: models.Session{
+				Device:    "Rapsodo MLM2PRO",
+				Player:    "Sam",
+				StartTime: timePointer(time.Date(2025, 3, 7, 10, 5, 0, 0, time.Local)),
+			},
+			wantMatch: true,
+		},
+		{
+			name: "Hyphenated player name",
+			row:  []string{"Rapsodo MLM2PRO: Anne-Marie Smith - 12/31/2024 11:59 PM"},
+			expected: models.Session{
+				Device:    "Rapsodo MLM2PRO",
+				Player:    "Anne-Marie Smith",
+				StartTime: timePointer(time.Date(2024, 12, 31, 23, 59, 0, 0, time.Local)),
+			},
+			wantMatch: true,
+		},
+		{name: "Header row", row: []string{"Club Type", "Club Brand"}},
+		{name: "Empty row", row: []string{}},
 	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var session models.Session
+			if matched := launchMonitor.ParseSessionRow(tt.row, &session); matched != tt.wantMatch {
+				t.Fatalf("ParseSessionRow() = %v, want %v", matched, tt.wantMatch)
+			}
+			if !reflect.DeepEqual(session, tt.expected) {
+				t.Errorf("ParseSessionRow() session = %+v, want %+v", session, tt.expected)
+			}
+		})
+	}
+}
+
+func timePointer(t time.Time) *time.Time {
+	return &t
 }
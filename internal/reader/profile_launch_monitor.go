@@ -0,0 +1,293 @@
+package reader
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"albatross/internal/models"
+	"albatross/internal/processors"
+)
+
+// Canonical fields a profile can map header aliases to
+const (
+	profileFieldClub  = "club"
+	profileFieldTotal = "total"
+	profileFieldSide  = "side"
+	profileFieldCarry = "carry"
+)
+
+// profileLaunchColumns names the optional launch data fields a profile can map,
+// which match the processed data's JSON names
+var profileLaunchColumns = launchColumns{
+	BallSpeed:       "ballSpeed",
+	LaunchAngle:     "launchAngle",
+	LaunchDirection: "launchDirection",
+	DescentAngle:    "descentAngle",
+	SpinRate:        "spinRate",
+	SpinAxis:        "spinAxis",
+	ClubSpeed:       "clubSpeed",
+	SmashFactor:     "smashFactor",
+	ClubBrand:       "clubBrand",
+	ClubModel:       "clubModel",
+}
+
+// profileFields lists every canonical field, requiredProfileFields the ones a profile must map,
+// distanceProfileFields and speedProfileFields the ones that may declare a distance or speed unit
+// and directionalProfileFields the ones the profile's direction encoding applies to
+var (
+	profileFields = []string{
+		profileFieldClub, profileFieldTotal, profileFieldSide, profileFieldCarry,
+		profileLaunchColumns.BallSpeed, profileLaunchColumns.LaunchAngle, profileLaunchColumns.LaunchDirection,
+		profileLaunchColumns.DescentAngle, profileLaunchColumns.SpinRate, profileLaunchColumns.SpinAxis,
+		profileLaunchColumns.ClubSpeed, profileLaunchColumns.SmashFactor, profileLaunchColumns.ClubBrand,
+		profileLaunchColumns.ClubModel,
+	}
+	requiredProfileFields    = []string{profileFieldClub, profileFieldTotal}
+	distanceProfileFields    = []string{profileFieldTotal, profileFieldSide, profileFieldCarry}
+	speedProfileFields       = []string{profileLaunchColumns.BallSpeed, profileLaunchColumns.ClubSpeed}
+	directionalProfileFields = []string{profileFieldSide, profileLaunchColumns.LaunchDirection, profileLaunchColumns.SpinAxis}
+)
+
+// Direction encodings a profile may declare for its side column
+const (
+	directionSigned   = "signed"   // Negative is left, "L"/"R" markers are also accepted
+	directionSuffix   = "suffix"   // Values such as "5.2 L" or "3.1 R"
+	directionInverted = "inverted" // Negative is right
+)
+
+// Profile declares how to read the CSV export of a launch monitor that has no dedicated reader
+type Profile struct {
+	Name        string              `json:"name" yaml:"name"`               // The type name used with -type
+	Aliases     []string            `json:"aliases" yaml:"aliases"`         // Other names accepted for the type
+	Description string              `json:"description" yaml:"description"` // A short human readable description
+	Title       string              `json:"title" yaml:"title"`             // Optional regular expression matching the export's title row
+	Columns     map[string][]string `json:"columns" yaml:"columns"`         // Canonical field ("club", "total", "side", "carry", "ballSpeed", ...) to header aliases
+	Units       map[string]string   `json:"units" yaml:"units"`             // Distance field to unit ("yards", "meters", "feet") or speed field to unit ("mph", "km/h", "m/s")
+	Direction   string              `json:"direction" yaml:"direction"`     // How directional columns encode direction: "signed", "suffix" or "inverted"
+	BlockEnd    []string            `json:"blockEnd" yaml:"blockEnd"`       // First cells of rows that end a data block (e.g. "Average", "Std. Dev.")
+}
+
+// LoadProfile reads and validates a launch monitor profile, which is YAML when the file's
+// extension is .yaml or .yml and JSON otherwise
+func LoadProfile(path string) (Profile, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return Profile{}, fmt.Errorf("reading profile '%s': %w", path, err)
+	}
+
+	var profile Profile
+	decode := json.Unmarshal
+	if extension := strings.ToLower(filepath.Ext(path)); extension == ".yaml" || extension == ".yml" {
+		decode = yaml.Unmarshal
+	}
+	if err := decode(content, &profile); err != nil {
+		return Profile{}, fmt.Errorf("decoding profile '%s': %w", path, err)
+	}
+
+	if err := profile.Validate(); err != nil {
+		return Profile{}, fmt.Errorf("invalid profile '%s': %w", path, err)
+	}
+	return profile, nil
+}
+
+// Validate checks that the profile names itself, maps the required fields and
+// only declares known fields, units and direction encodings
+func (profile Profile) Validate() error {
+	if strings.TrimSpace(profile.Name) == "" {
+		return fmt.Errorf("missing name")
+	}
+
+	for field := range profile.Columns {
+		if !containsField(profileFields, field) {
+			return fmt.Errorf("unknown column field '%s'", field)
+		}
+	}
+	for _, field := range requiredProfileFields {
+		if len(profile.Columns[field]) == 0 {
+			return fmt.Errorf("no header aliases for required field '%s'", field)
+		}
+	}
+
+	for field, unit := range profile.Units {
+		if !containsField(distanceProfileFields, field) && !containsField(speedProfileFields, field) {
+			return fmt.Errorf("units declared for field '%s', which is not a distance or speed", field)
+		}
+		if _, ok := profile.unit(field); !ok && strings.TrimSpace(unit) != "" {
+			return fmt.Errorf("unknown unit '%s' for field '%s'", unit, field)
+		}
+	}
+
+	switch strings.ToLower(profile.Direction) {
+	case "", directionSigned, directionSuffix, directionInverted:
+	default:
+		return fmt.Errorf("unknown direction encoding '%s'", profile.Direction)
+	}
+
+	if profile.Title != "" {
+		if _, err := regexp.Compile(profile.Title); err != nil {
+			return fmt.Errorf("invalid title pattern: %w", err)
+		}
+	}
+	return nil
+}
+
+// Registration describes the profile as a launch monitor so it can be registered and looked up like a built-in reader
+func (profile Profile) Registration() Registration {
+	// Declared units are converted by the profile, and otherwise the headers may give them
+	signature := Signature{Units: UnitsFromHeaders}
+	if len(profile.Units) > 0 {
+		signature.Units = UnitsKnown
+	}
+	if profile.Title != "" {
+		signature.Title = regexp.MustCompile("(?i)" + profile.Title)
+	}
+	for _, field := range profileFields {
+		for _, alias := range profile.Columns[field] {
+			signature.Columns = append(signature.Columns, normalizeProfileHeader(alias))
+		}
+	}
+	for _, marker := range profile.BlockEnd {
+		signature.BlockEndMarkers = append(signature.BlockEndMarkers, normalizeProfileHeader(marker))
+	}
+
+	description := profile.Description
+	if description == "" {
+		description = "Profile-defined CSV export"
+	}
+
+	return Registration{
+		Name:        profile.Name,
+		Aliases:     profile.Aliases,
+		Description: description,
+		Signature:   signature,
+		New: func() models.LaunchMonitor {
+			return NewProfileLaunchMonitor(profile)
+		},
+	}
+}
+
+// ProfileLaunchMonitor implements the LaunchMonitor interface for exports described by a Profile
+type ProfileLaunchMonitor struct {
+	profile Profile
+}
+
+// NewProfileLaunchMonitor creates and returns a new ProfileLaunchMonitor for the given profile
+func NewProfileLaunchMonitor(profile Profile) models.LaunchMonitor {
+	return &ProfileLaunchMonitor{profile: profile}
+}
+
+// ParseRow converts a row of strings into a RawShotData struct keyed by the profile's canonical fields.
+// It returns an error if a required field's column is missing or the side value cannot be read.
+func (launchMonitor ProfileLaunchMonitor) ParseRow(row []string, headers []string) (models.RawShotData, error) {
+	columns, err := mapRow(row, headers)
+	if err != nil {
+		return models.RawShotData{}, err
+	}
+
+	data := make(map[string]string)
+	for _, field := range profileFields {
+		for _, alias := range launchMonitor.profile.Columns[field] {
+			header := normalizeProfileHeader(alias)
+			value, ok := columns[header]
+			if !ok {
+				continue
+			}
+			// A unit in the header has already been converted, so the declared unit only applies to headers without one
+			if unit, declared := launchMonitor.profile.unit(field); declared {
+				if _, _, inHeader := SplitHeaderUnit(header); !inHeader {
+					value = unit.ToImperial(value)
+				}
+			}
+			data[field] = value
+			break
+		}
+	}
+
+	for _, field := range requiredProfileFields {
+		if _, ok := data[field]; !ok {
+			return models.RawShotData{}, fmt.Errorf("missing column for field '%s'", field)
+		}
+	}
+
+	if err := signDirectionalColumns(data, directionalProfileFields); err != nil {
+		return models.RawShotData{}, err
+	}
+
+	return models.RawShotData{
+		LaunchMonitorType: launchMonitor.profile.Name,
+		Data:              data,
+	}, nil
+}
+
+// ProcessRawData converts RawShotData into ProcessedShotData, applying the profile's direction encoding
+func (launchMonitor ProfileLaunchMonitor) ProcessRawData(rawData models.RawShotData) (models.ProcessedShotData, error) {
+	fields := shotFields{data: rawData.Data}
+	clubType := fields.text(profileFieldClub)
+	carryDistance := fields.optional(profileFieldCarry)
+	totalDistance := fields.number(profileFieldTotal)
+
+	// Side is optional in a profile, but a profile that maps it must have it for every shot
+	var sideCarry float64
+	if _, mapped := launchMonitor.profile.Columns[profileFieldSide]; mapped {
+		sideCarry = fields.number(profileFieldSide)
+	}
+
+	normalizedClub := processors.NormalizeClubType(clubType)
+	shotType := processors.DetermineShotType(normalizedClub)
+
+	processed := models.ProcessedShotData{
+		Carry: carryDistance,
+		Club:  normalizedClub,
+		Roll:  rollDistance(totalDistance, carryDistance),
+		Type:  shotType,
+		Total: totalDistance,
+		Side:  sideCarry,
+	}
+	readLaunchData(&processed, &fields, profileLaunchColumns)
+
+	if strings.ToLower(launchMonitor.profile.Direction) == directionInverted {
+		processed.Side = -processed.Side
+		processed.LaunchDirection = -processed.LaunchDirection
+		processed.SpinAxis = -processed.SpinAxis
+	}
+
+	if fields.err != nil {
+		return models.ProcessedShotData{}, fields.err
+	}
+	return processed, nil
+}
+
+// unit returns the distance or speed unit the profile declares for a field
+func (profile Profile) unit(field string) (ColumnUnit, bool) {
+	label, ok := profile.Units[field]
+	if !ok {
+		return ColumnUnit{}, false
+	}
+	if containsField(distanceProfileFields, field) {
+		unit, ok := models.ParseDistanceUnit(label)
+		return ColumnUnit{Distance: unit}, ok
+	}
+	unit, ok := models.ParseSpeedUnit(label)
+	return ColumnUnit{Speed: unit}, ok
+}
+
+// containsField reports whether a field is one of the given profile fields
+func containsField(fields []string, field string) bool {
+	for _, candidate := range fields {
+		if field == candidate {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeProfileHeader lowercases and trims a header alias the same way the parser normalizes header rows
+func normalizeProfileHeader(header string) string {
+	return NormalizeHeader(header)
+}
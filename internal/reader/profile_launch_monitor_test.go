@@ -0,0 +1,247 @@
+package reader
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"albatross/internal/models"
+)
+
+func TestLoadProfile(t *testing.T) {
+	profile, err := LoadProfile("../../examples/profiles/uneekor.json")
+	if err != nil {
+		t.Fatalf("LoadProfile failed: %v", err)
+	}
+	if profile.Name != "uneekor" || profile.Direction != "suffix" {
+		t.Errorf("Unexpected profile: %+v", profile)
+	}
+
+	// The YAML example describes the same profile
+	yamlProfile, err := LoadProfile("../../examples/profiles/uneekor.yaml")
+	if err != nil {
+		t.Fatalf("LoadProfile failed for YAML: %v", err)
+	}
+	if !reflect.DeepEqual(yamlProfile, profile) {
+		t.Errorf("YAML profile = %+v, want %+v", yamlProfile, profile)
+	}
+
+	// Apostrophes in plain scalars and escapes in double-quoted ones are read as YAML reads them
+	tempDir := t.TempDir()
+	quotedYAML := filepath.Join(tempDir, "quoted.yaml")
+	quoted := "name: homesim\ndescription: Palmer's garage sim\ntitle: \"^Home sim\\x21\"\ncolumns:\n  club: [Club]\n  total: [Total]\n"
+	if err := os.WriteFile(quotedYAML, []byte(quoted), 0o644); err != nil {
+		t.Fatalf("Failed to write profile: %v", err)
+	}
+	quotedProfile, err := LoadProfile(quotedYAML)
+	if err != nil {
+		t.Fatalf("LoadProfile failed for YAML with quotes: %v", err)
+	}
+	if quotedProfile.Description != "Palmer's garage sim" || quotedProfile.Title != "^Home sim!" {
+		t.Errorf("Unexpected quoted profile: %+v", quotedProfile)
+	}
+
+	invalidYAML := filepath.Join(tempDir, "invalid.yml")
+	if err := os.WriteFile(invalidYAML, []byte("name: broken\ncolumns: Club\n"), 0o644); err != nil {
+		t.Fatalf("Failed to write profile: %v", err)
+	}
+	if _, err := LoadProfile(invalidYAML); err == nil {
+		t.Errorf("Expected error for a YAML profile with a scalar for columns, got nil")
+	}
+
+	invalidFile := filepath.Join(tempDir, "invalid.json")
+	if err := os.WriteFile(invalidFile, []byte(`{"name": "broken"`), 0o644); err != nil {
+		t.Fatalf("Failed to write profile: %v", err)
+	}
+	if _, err := LoadProfile(invalidFile); err == nil {
+		t.Errorf("Expected error for malformed profile, got nil")
+	}
+	if _, err := LoadProfile(filepath.Join(tempDir, "missing.json")); err == nil {
+		t.Errorf("Expected error for missing profile, got nil")
+	}
+}
+
+func TestProfileValidate(t *testing.T) {
+	columns := map[string][]string{"club": {"Club"}, "total": {"Total"}}
+
+	tests := []struct {
+		name    string
+		profile Profile
+		wantErr bool
+	}{
+		{"Minimal profile", Profile{Name: "sim", Columns: columns}, false},
+		{"Missing name", Profile{Columns: columns}, true},
+		{"Missing required field", Profile{Name: "sim", Columns: map[string][]string{"club": {"Club"}}}, true},
+		{"Unknown field", Profile{Name: "sim", Columns: map[string][]string{"club": {"Club"}, "total": {"Total"}, "spin": {"Spin"}}}, true},
+		{"Metric units", Profile{Name: "sim", Columns: columns, Units: map[string]string{"total": "Meters"}}, false},
+		{"Unknown unit", Profile{Name: "sim", Columns: columns, Units: map[string]string{"total": "furlongs"}}, true},
+		{"Units on club", Profile{Name: "sim", Columns: columns, Units: map[string]string{"club": "yards"}}, true},
+		{"Units on carry", Profile{Name: "sim", Columns: columns, Units: map[string]string{"carry": "meters"}}, false},
+		{"Units on ball speed", Profile{Name: "sim", Columns: columns, Units: map[string]string{"ballSpeed": "meters"}}, true},
+		{"Launch data fields", Profile{Name: "sim", Columns: map[string][]string{"club": {"Club"}, "total": {"Total"}, "ballSpeed": {"Ball Speed"}, "spinRate": {"Spin"}}}, false},
+		{"Unknown direction", Profile{Name: "sim", Columns: columns, Direction: "sideways"}, true},
+		{"Invalid title", Profile{Name: "sim", Columns: columns, Title: "("}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.profile.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestProfileRegistration(t *testing.T) {
+	profile := Profile{
+		Name:     "homesim",
+		Title:    "^home sim export",
+		Columns:  map[string][]string{"club": {"Club"}, "total": {"Total (m)"}, "side": {"Side (m)"}},
+		BlockEnd: []string{"Average", "Std. Dev."},
+	}
+
+	registration := profile.Registration()
+	if registration.Name != "homesim" || registration.Description == "" {
+		t.Errorf("Unexpected registration: %+v", registration)
+	}
+	if !registration.Signature.Title.MatchString("Home Sim Export 2024-09-05") {
+		t.Errorf("Expected title pattern to match case-insensitively")
+	}
+
+	// Header units are normalized to the yards values are converted into
+	expectedColumns := []string{"club", "total (yds)", "side (yds)"}
+	if !reflect.DeepEqual(registration.Signature.Columns, expectedColumns) {
+		t.Errorf("Signature columns = %v, want %v", registration.Signature.Columns, expectedColumns)
+	}
+	if !registration.Signature.EndsBlock([]string{"Std. Dev.", "3.2"}) {
+		t.Errorf("Expected 'Std. Dev.' row to end a block")
+	}
+	if _, ok := registration.New().(*ProfileLaunchMonitor); !ok {
+		t.Errorf("Expected New to create a ProfileLaunchMonitor")
+	}
+
+	// Without declared units, the units come from the headers
+	if registration.Signature.Units != UnitsFromHeaders {
+		t.Errorf("Signature units = %v, want UnitsFromHeaders", registration.Signature.Units)
+	}
+	profile.Units = map[string]string{"total": "meters", "side": "meters"}
+	if units := profile.Registration().Signature.Units; units != UnitsKnown {
+		t.Errorf("Signature units = %v, want UnitsKnown", units)
+	}
+}
+
+func TestProfileLaunchMonitor(t *testing.T) {
+	profile := Profile{
+		Name:      "homesim",
+		Columns:   map[string][]string{"club": {"Club Name", "Club"}, "total": {"Total"}, "side": {"Offline"}},
+		Units:     map[string]string{"total": "meters", "side": "meters"},
+		Direction: "suffix",
+	}
+	launchMonitor := NewProfileLaunchMonitor(profile)
+	headers := []string{"club", "carry", "total", "offline"}
+
+	rawData, err := launchMonitor.ParseRow([]string{"7 Iron", "130.0", "137.0", "4.0 L"}, headers)
+	if err != nil {
+		t.Fatalf("ParseRow failed: %v", err)
+	}
+
+	expected := models.RawShotData{
+		LaunchMonitorType: "homesim",
+		Data:              map[string]string{"club": "7 Iron", "total": "149.83", "side": "-4.37"},
+	}
+	if !reflect.DeepEqual(rawData, expected) {
+		t.Errorf("ParseRow() = %v, want %v", rawData, expected)
+	}
+
+	processed, err := launchMonitor.ProcessRawData(rawData)
+	if err != nil {
+		t.Fatalf("ProcessRawData failed: %v", err)
+	}
+	if processed.Club != "7i" || processed.Type != "Approach" {
+		t.Errorf("Unexpected club or type: %+v", processed)
+	}
+	if math.Abs(processed.Total-149.825) > 0.01 || math.Abs(processed.Side-(-4.374)) > 0.01 {
+		t.Errorf("Expected distances converted to yards, got Total %.3f Side %.3f", processed.Total, processed.Side)
+	}
+
+	if _, err := launchMonitor.ParseRow([]string{"7 Iron", "130.0"}, []string{"club", "carry"}); err == nil {
+		t.Errorf("Expected error for missing total column, got nil")
+	}
+	if _, err := launchMonitor.ParseRow([]string{"7 Iron", "130.0", "137.0", "far left"}, headers); err == nil {
+		t.Errorf("Expected error for unreadable side value, got nil")
+	}
+}
+
+func TestProfileLaunchMonitorInvertedDirection(t *testing.T) {
+	profile := Profile{
+		Name:      "mirrored",
+		Columns:   map[string][]string{"club": {"Club"}, "total": {"Total"}, "side": {"Side"}},
+		Direction: "inverted",
+	}
+	launchMonitor := NewProfileLaunchMonitor(profile)
+
+	processed, err := launchMonitor.ProcessRawData(models.RawShotData{Data: map[string]string{"club": "Driver", "total": "250", "side": "-6", "launchDirection": "1.5"}})
+	if err != nil {
+		t.Fatalf("ProcessRawData failed: %v", err)
+	}
+	if processed.Side != 6 || processed.LaunchDirection != -1.5 {
+		t.Errorf("Expected inverted side of 6 and launch direction of -1.5, got %v and %v", processed.Side, processed.LaunchDirection)
+	}
+}
+
+func TestProfileLaunchMonitorLaunchData(t *testing.T) {
+	profile := Profile{
+		Name: "homesim",
+		Columns: map[string][]string{
+			"club":      {"Club"},
+			"carry":     {"Carry"},
+			"total":     {"Total"},
+			"ballSpeed": {"Ball Speed"},
+			"spinAxis":  {"Spin Axis"},
+			"clubModel": {"Model"},
+		},
+		Units: map[string]string{"carry": "meters", "total": "meters"},
+	}
+	launchMonitor := NewProfileLaunchMonitor(profile)
+	headers := []string{"club", "carry", "total", "ball speed", "spin axis", "model"}
+
+	rawData, err := launchMonitor.ParseRow([]string{"7 Iron", "130.0", "137.0", "118.5", "2.1 L", "T200"}, headers)
+	if err != nil {
+		t.Fatalf("ParseRow failed: %v", err)
+	}
+
+	processed, err := launchMonitor.ProcessRawData(rawData)
+	if err != nil {
+		t.Fatalf("ProcessRawData failed: %v", err)
+	}
+	if math.Abs(processed.Carry-142.170) > 0.01 || math.Abs(processed.Roll-7.66) > 0.01 {
+		t.Errorf("Expected carry and roll in yards, got Carry %.3f Roll %.3f", processed.Carry, processed.Roll)
+	}
+	if processed.BallSpeed != 118.5 || processed.SpinAxis != -2.1 || processed.ClubModel != "T200" {
+		t.Errorf("Unexpected launch data: %+v", processed)
+	}
+}
+
+func TestProfileLaunchMonitorHeaderUnits(t *testing.T) {
+	profile := Profile{
+		Name:    "homesim",
+		Columns: map[string][]string{"club": {"Club"}, "total": {"Total (m)"}, "ballSpeed": {"Ball Speed"}},
+		Units:   map[string]string{"total": "meters", "ballSpeed": "km/h"},
+	}
+	launchMonitor := NewProfileLaunchMonitor(profile)
+
+	// The parser has already converted the total, whose header gives its unit, into yards
+	headers := []string{"club", "total (yds)", "ball speed"}
+	rawData, err := launchMonitor.ParseRow([]string{"7 Iron", "149.83", "190.0"}, headers)
+	if err != nil {
+		t.Fatalf("ParseRow failed: %v", err)
+	}
+
+	expected := map[string]string{"club": "7 Iron", "total": "149.83", "ballSpeed": "118.06"}
+	if !reflect.DeepEqual(rawData.Data, expected) {
+		t.Errorf("ParseRow() data = %v, want %v", rawData.Data, expected)
+	}
+}
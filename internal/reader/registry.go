@@ -0,0 +1,155 @@
+package reader
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"albatross/internal/models"
+)
+
+// defaultBlockEndMarkers close a data block when no markers are declared, matching the
+// summary rows MLM2Pro writes beneath each club
+var defaultBlockEndMarkers = []string{"average"}
+
+// UnitSource says how the units of a launch monitor's exported distances and speeds are established
+type UnitSource int
+
+const (
+	// UnitsAssumed is for exports that don't give their units, which are read as yards and mph
+	UnitsAssumed UnitSource = iota
+	// UnitsFromHeaders is for exports whose headers, or a unit row beneath them, give their units
+	UnitsFromHeaders
+	// UnitsKnown is for exports always in the same units, which the reader converts itself
+	UnitsKnown
+)
+
+// Signature describes what identifies the export of a launch monitor, how its data blocks are laid out
+// and where the units of its values come from
+type Signature struct {
+	Title           *regexp.Regexp // A title row that only this launch monitor writes
+	Columns         []string       // Normalized header names expected in CSV exports
+	DocumentKeys    []string       // Lowercased top-level keys expected in JSON exports
+	BlockEndMarkers []string       // Lowercased prefixes of a row's first cell that end a data block
+	Units           UnitSource     // How the export's units are established; assumed to be yards and mph when not declared
+	HeightColumns   []string       // Normalized header names of columns holding heights, which are converted into feet rather than yards
+}
+
+// EndsBlock reports whether a row closes the current data block, based on the
+// signature's block end markers or "average" if none are declared
+func (signature Signature) EndsBlock(row []string) bool {
+	if len(row) == 0 {
+		return false
+	}
+
+	markers := signature.BlockEndMarkers
+	if len(markers) == 0 {
+		markers = defaultBlockEndMarkers
+	}
+
+	firstCell := strings.ToLower(strings.TrimSpace(row[0]))
+	for _, marker := range markers {
+		if strings.HasPrefix(firstCell, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchColumns counts how many of the signature's columns appear in a row of normalized headers
+func (signature Signature) MatchColumns(headers []string) int {
+	present := make(map[string]bool, len(headers))
+	for _, header := range headers {
+		present[header] = true
+	}
+
+	matched := 0
+	for _, column := range signature.Columns {
+		if present[column] {
+			matched++
+		}
+	}
+	return matched
+}
+
+// Registration describes a launch monitor reader and how to recognise its exports
+type Registration struct {
+	Name        string                      // The canonical type name used with -type (e.g. "mlm2pro")
+	Aliases     []string                    // Other names accepted for the type
+	Description string                      // A short human readable description of the device and export
+	Signature   Signature                   // What identifies the launch monitor's exports
+	New         func() models.LaunchMonitor // Creates a new reader for the launch monitor
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]Registration)
+	names      = make(map[string]string)
+)
+
+// Register makes a launch monitor reader available by its name and aliases.
+// It panics if the name or an alias is empty or already registered, or if New is nil.
+func Register(registration Registration) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if registration.New == nil {
+		panic(fmt.Sprintf("reader: Register %q with nil New", registration.Name))
+	}
+
+	keys := append([]string{registration.Name}, registration.Aliases...)
+	for _, key := range keys {
+		key = strings.ToLower(key)
+		if key == "" {
+			panic(fmt.Sprintf("reader: Register %q with empty name or alias", registration.Name))
+		}
+		if existing, ok := names[key]; ok {
+			panic(fmt.Sprintf("reader: Register called twice for %q (already registered by %q)", key, existing))
+		}
+	}
+
+	registration.Name = strings.ToLower(registration.Name)
+	for _, key := range keys {
+		names[strings.ToLower(key)] = registration.Name
+	}
+	registry[registration.Name] = registration
+}
+
+// Lookup returns the registration for a launch monitor name or alias, ignoring case
+func Lookup(name string) (Registration, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	canonical, ok := names[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return Registration{}, false
+	}
+	return registry[canonical], true
+}
+
+// Registrations returns every registered launch monitor, sorted by name
+func Registrations() []Registration {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	registrations := make([]Registration, 0, len(registry))
+	for _, registration := range registry {
+		registrations = append(registrations, registration)
+	}
+	sort.Slice(registrations, func(i, j int) bool {
+		return registrations[i].Name < registrations[j].Name
+	})
+	return registrations
+}
+
+// Names returns the canonical name of every registered launch monitor, sorted
+func Names() []string {
+	registrations := Registrations()
+	registeredNames := make([]string, len(registrations))
+	for i, registration := range registrations {
+		registeredNames[i] = registration.Name
+	}
+	return registeredNames
+}
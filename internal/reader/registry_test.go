@@ -0,0 +1,129 @@
+package reader
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		found    bool
+	}{
+		{"Canonical name", "mlm2pro", "mlm2pro", true},
+		{"Mixed case", "MLM2Pro", "mlm2pro", true},
+		{"Alias", "r10", "garmin", true},
+		{"Alias with whitespace", " gcquad ", "foresight", true},
+		{"Unknown", "unknown", "", false},
+		{"Empty", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registration, found := Lookup(tt.input)
+			if found != tt.found {
+				t.Fatalf("Lookup(%q) found = %v, want %v", tt.input, found, tt.found)
+			}
+			if registration.Name != tt.expected {
+				t.Errorf("Lookup(%q) = %q, want %q", tt.input, registration.Name, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRegistrationUnits(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected UnitSource
+	}{
+		{"mlm2pro", UnitsAssumed},
+		{"shotpattern", UnitsAssumed},
+		{"flightscope", UnitsFromHeaders},
+		{"garmin", UnitsFromHeaders},
+		{"trackman", UnitsKnown},
+		{"gspro", UnitsKnown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registration, _ := Lookup(tt.name)
+			if registration.Signature.Units != tt.expected {
+				t.Errorf("%s units = %v, want %v", tt.name, registration.Signature.Units, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNames(t *testing.T) {
+	expected := []string{"flightscope", "foresight", "garmin", "gspro", "mlm2pro", "shotpattern", "skytrak", "trackman"}
+	if result := Names(); !reflect.DeepEqual(result, expected) {
+		t.Errorf("Names() = %v, want %v", result, expected)
+	}
+
+	for _, registration := range Registrations() {
+		if registration.Description == "" {
+			t.Errorf("Registration %q has no description", registration.Name)
+		}
+		if registration.New() == nil {
+			t.Errorf("Registration %q created a nil launch monitor", registration.Name)
+		}
+	}
+}
+
+func TestRegisterRejectsDuplicates(t *testing.T) {
+	tests := []struct {
+		name         string
+		registration Registration
+	}{
+		{"Duplicate name", Registration{Name: "MLM2Pro", New: NewMLM2ProLaunchMonitor}},
+		{"Duplicate alias", Registration{Name: "brand-new", Aliases: []string{"r10"}, New: NewGarminLaunchMonitor}},
+		{"Missing constructor", Registration{Name: "no-constructor"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Register(%+v) did not panic", tt.registration)
+				}
+			}()
+			Register(tt.registration)
+		})
+	}
+
+	if _, found := Lookup("brand-new"); found {
+		t.Errorf("Rejected registration was partially registered")
+	}
+}
+
+func TestSignatureMatchColumns(t *testing.T) {
+	signature := Signature{Columns: []string{"club", "carry", "total"}}
+	if matched := signature.MatchColumns([]string{"club", "total", "offline"}); matched != 2 {
+		t.Errorf("MatchColumns() = %d, want 2", matched)
+	}
+}
+
+func TestSignatureEndsBlock(t *testing.T) {
+	tests := []struct {
+		name      string
+		signature Signature
+		row       []string
+		expected  bool
+	}{
+		{"Default average row", Signature{}, []string{"Average", "", "86.9"}, true},
+		{"Default data row", Signature{}, []string{"pw", "U.S. Kids Golf"}, false},
+		{"Declared marker", Signature{BlockEndMarkers: []string{"std. dev."}}, []string{" Std. Dev.", "4.5"}, true},
+		{"Declared markers replace default", Signature{BlockEndMarkers: []string{"total"}}, []string{"Average"}, false},
+		{"Empty row", Signature{}, []string{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.signature.EndsBlock(tt.row); result != tt.expected {
+				t.Errorf("EndsBlock(%v) = %v, want %v", tt.row, result, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package reader
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"albatross/internal/models"
+	"albatross/internal/processors"
+)
+
+// launchColumns names the columns a launch monitor exports its ball and club launch
+// measurements in. Measurements a launch monitor doesn't export are left blank.
+type launchColumns struct {
+	BallSpeed       string
+	LaunchAngle     string
+	LaunchDirection string
+	DescentAngle    string
+	SpinRate        string
+	SpinAxis        string
+	Backspin        string // Backspin component, used with Sidespin when the total spin rate isn't exported
+	Sidespin        string // Sidespin component, positive spinning the ball to the right
+	ClubSpeed       string
+	SmashFactor     string
+	ClubBrand       string
+	ClubModel       string
+}
+
+// mapRow pairs each header with the trimmed value in the same column of the row.
+// It returns an error if the row has fewer columns than there are headers.
+func mapRow(row []string, headers []string) (map[string]string, error) {
+	if len(row) < len(headers) {
+		return nil, fmt.Errorf("insufficient columns")
+	}
+
+	data := make(map[string]string)
+	for i, header := range headers {
+		data[header] = strings.TrimSpace(row[i])
+	}
+	return data, nil
+}
+
+// signDirectionalColumns rewrites directional values such as "5.2 L" in the given
+// columns as signed numbers, left negative and right positive. Missing values are
+// left alone; any other value that cannot be read is a *models.FieldError.
+func signDirectionalColumns(data map[string]string, columns []string) error {
+	for _, column := range columns {
+		value, ok := data[column]
+		if !ok || isMissing(value) {
+			continue
+		}
+		signed, err := processors.ParseDirection(value)
+		if err != nil {
+			return &models.FieldError{Field: column, Value: value, Reason: err.Error()}
+		}
+		data[column] = strconv.FormatFloat(signed, 'f', -1, 64)
+	}
+	return nil
+}
+
+// isMissing reports whether a value marks a measurement the launch monitor didn't take.
+// Exports leave these cells blank or fill them with a dash.
+func isMissing(value string) bool {
+	return value == "" || value == "-" || value == "--"
+}
+
+// shotFields reads the columns of a shot's raw data, keeping the first error so a
+// reader can read every column it needs and check for a problem once
+type shotFields struct {
+	data map[string]string
+	err  error
+}
+
+// text reads a column that every shot must have as text, such as the club
+func (fields *shotFields) text(column string) string {
+	value := fields.data[column]
+	if isMissing(value) {
+		fields.fail(column, value, "missing value")
+	}
+	return value
+}
+
+// number reads a numeric column that every shot must have
+func (fields *shotFields) number(column string) float64 {
+	value := fields.data[column]
+	if isMissing(value) {
+		fields.fail(column, value, "missing value")
+		return 0
+	}
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		fields.fail(column, value, fmt.Sprintf("invalid number %q", value))
+		return 0
+	}
+	return number
+}
+
+// optional reads a numeric column that may be absent or missing, which reads as zero.
+// A value that is present but unreadable is still an error.
+func (fields *shotFields) optional(column string) float64 {
+	if column == "" || isMissing(fields.data[column]) {
+		return 0
+	}
+	return fields.number(column)
+}
+
+// has reports whether the shot has a value in a column
+func (fields *shotFields) has(column string) bool {
+	return !isMissing(fields.data[column])
+}
+
+// fail records a field error unless an earlier one has been recorded
+func (fields *shotFields) fail(column, value, reason string) {
+	if fields.err == nil {
+		fields.err = &models.FieldError{Field: column, Value: value, Reason: reason}
+	}
+}
+
+// readLaunchData copies the launch measurements named by columns into the shot.
+// Missing values are left at zero. The spin rate and axis are derived from the backspin
+// and sidespin components, and the smash factor from the ball and club speeds, when the
+// launch monitor doesn't export them. Unreadable values are recorded in fields.
+func readLaunchData(shot *models.ProcessedShotData, fields *shotFields, columns launchColumns) {
+	shot.BallSpeed = fields.optional(columns.BallSpeed)
+	shot.LaunchAngle = fields.optional(columns.LaunchAngle)
+	shot.LaunchDirection = fields.optional(columns.LaunchDirection)
+	shot.DescentAngle = fields.optional(columns.DescentAngle)
+	shot.SpinRate = fields.optional(columns.SpinRate)
+	shot.SpinAxis = fields.optional(columns.SpinAxis)
+	shot.ClubSpeed = fields.optional(columns.ClubSpeed)
+	shot.SmashFactor = fields.optional(columns.SmashFactor)
+	if columns.ClubBrand != "" {
+		shot.ClubBrand = fields.data[columns.ClubBrand]
+	}
+	if columns.ClubModel != "" {
+		shot.ClubModel = fields.data[columns.ClubModel]
+	}
+
+	if shot.SpinRate == 0 {
+		backspin := fields.optional(columns.Backspin)
+		sidespin := fields.optional(columns.Sidespin)
+		if backspin != 0 || sidespin != 0 {
+			shot.SpinRate = math.Round(math.Hypot(backspin, sidespin))
+			shot.SpinAxis = roundHundredths(math.Atan2(sidespin, backspin) * 180 / math.Pi)
+		}
+	}
+	if shot.SmashFactor == 0 && shot.BallSpeed > 0 && shot.ClubSpeed > 0 {
+		shot.SmashFactor = roundHundredths(shot.BallSpeed / shot.ClubSpeed)
+	}
+}
+
+// rollDistance returns the roll between carry and total, which is only known when both were measured
+func rollDistance(total, carry float64) float64 {
+	if total <= 0 || carry <= 0 {
+		return 0
+	}
+	return roundHundredths(total - carry)
+}
+
+// roundHundredths rounds a derived value to two decimal places, the precision launch monitors export
+func roundHundredths(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -0,0 +1,87 @@
+package reader
+
+import (
+	"strings"
+
+	"albatross/internal/models"
+	"albatross/internal/processors"
+)
+
+// ShotPatternLaunchMonitor implements the LaunchMonitor interface for ShotPattern CSV files,
+// the format Albatross writes and the ShotPattern app exports. Reading them back in lets
+// processed sessions be merged, re-targeted or converted without the original export.
+type ShotPatternLaunchMonitor struct{}
+
+// NewShotPatternLaunchMonitor creates and returns a new ShotPatternLaunchMonitor instance
+func NewShotPatternLaunchMonitor() models.LaunchMonitor {
+	return &ShotPatternLaunchMonitor{}
+}
+
+func init() {
+	Register(Registration{
+		Name:        "shotpattern",
+		Aliases:     []string{"albatross", "processed"},
+		Description: "ShotPattern CSV (Club,Type,Target,Total,Side) as written by Albatross or exported by the ShotPattern app",
+		Signature: Signature{
+			Columns: []string{"club", "type", "target", "total", "side"},
+			Units:   UnitsAssumed,
+		},
+		New: NewShotPatternLaunchMonitor,
+	})
+}
+
+// ParseRow converts a row of strings into a RawShotData struct for ShotPattern data
+func (launchMonitor ShotPatternLaunchMonitor) ParseRow(row []string, headers []string) (models.RawShotData, error) {
+	data, err := mapRow(row, headers)
+	if err != nil {
+		return models.RawShotData{}, err
+	}
+
+	return models.RawShotData{
+		LaunchMonitorType: "ShotPattern",
+		Data:              data,
+	}, nil
+}
+
+// ProcessRawData converts RawShotData into ProcessedShotData for ShotPattern data.
+// The shot type, target, any flags and outlier reason are kept from the file; the type is derived from
+// the club when the column is blank. Shots the Distance column says were written with their carry
+// distance keep it as their carry too, so they can be targeted on carry again.
+func (launchMonitor ShotPatternLaunchMonitor) ProcessRawData(rawData models.RawShotData) (models.ProcessedShotData, error) {
+	fields := shotFields{data: rawData.Data}
+	normalizedClub := processors.NormalizeClubType(fields.text("club"))
+	target := fields.optional("target")
+	totalDistance := fields.number("total")
+	sideCarry := fields.number("side")
+	if fields.err != nil {
+		return models.ProcessedShotData{}, fields.err
+	}
+
+	shotType := rawData.Data["type"]
+	if shotType == "" {
+		shotType = processors.DetermineShotType(normalizedClub)
+	}
+
+	var carry float64
+	targetDistance := strings.ToLower(strings.TrimSpace(rawData.Data["distance"]))
+	if targetDistance == "carry" {
+		carry = totalDistance
+	}
+
+	var flags []string
+	if flag := rawData.Data["flag"]; flag != "" {
+		flags = strings.Split(flag, models.FlagSeparator)
+	}
+
+	return models.ProcessedShotData{
+		Club:           normalizedClub,
+		Type:           shotType,
+		Target:         target,
+		Carry:          carry,
+		Total:          totalDistance,
+		Side:           sideCarry,
+		Flags:          flags,
+		Outlier:        rawData.Data["outlier"],
+		TargetDistance: targetDistance,
+	}, nil
+}
@@ -0,0 +1,80 @@
+package reader
+
+import (
+	"reflect"
+	"testing"
+
+	"albatross/internal/models"
+)
+
+func TestShotPatternLaunchMonitorParseRow(t *testing.T) {
+	launchMonitor := ShotPatternLaunchMonitor{}
+	headers := []string{"club", "type", "target", "total", "side"}
+	row := []string{"Pw", "Approach", "94.15", "85.90", "-2.20"}
+
+	expected := models.RawShotData{
+		LaunchMonitorType: "ShotPattern",
+		Data: map[string]string{
+			"club":   "Pw",
+			"type":   "Approach",
+			"target": "94.15",
+			"total":  "85.90",
+			"side":   "-2.20",
+		},
+	}
+
+	result, err := launchMonitor.ParseRow(row, headers)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("ParseRow() = %v, want %v", result, expected)
+	}
+}
+
+func TestShotPatternLaunchMonitorProcessRawData(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     map[string]string
+		expected models.ProcessedShotData
+	}{
+		{
+			name:     "Albatross output",
+			data:     map[string]string{"club": "Pw", "type": "Approach", "target": "94.15", "total": "85.90", "side": "-2.20"},
+			expected: models.ProcessedShotData{Club: "Pw", Type: "Approach", Target: 94.15, Total: 85.9, Side: -2.2},
+		},
+		{
+			name:     "Blank type",
+			data:     map[string]string{"club": "Driver", "type": "", "target": "", "total": "250", "side": "4"},
+			expected: models.ProcessedShotData{Club: "Dr", Type: "Tee", Total: 250, Side: 4},
+		},
+		{
+			name:     "Flagged shot",
+			data:     map[string]string{"club": "Dr", "type": "Tee", "target": "255", "total": "180", "side": "2", "flag": "zero spin; carry greater than total"},
+			expected: models.ProcessedShotData{Club: "Dr", Type: "Tee", Target: 255, Total: 180, Side: 2, Flags: []string{"zero spin", "carry greater than total"}},
+		},
+		{
+			name:     "Outlier",
+			data:     map[string]string{"club": "7i", "type": "Approach", "target": "150", "total": "95", "side": "-8", "outlier": "total 95.0 below IQR fence 140.0"},
+			expected: models.ProcessedShotData{Club: "7i", Type: "Approach", Target: 150, Total: 95, Side: -8, Outlier: "total 95.0 below IQR fence 140.0"},
+		},
+		{
+			name:     "Carry distance",
+			data:     map[string]string{"club": "7i", "type": "Approach", "target": "143", "total": "141.5", "side": "-2", "distance": "carry"},
+			expected: models.ProcessedShotData{Club: "7i", Type: "Approach", Target: 143, Carry: 141.5, Total: 141.5, Side: -2, TargetDistance: "carry"},
+		},
+	}
+
+	launchMonitor := ShotPatternLaunchMonitor{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := launchMonitor.ProcessRawData(models.RawShotData{LaunchMonitorType: "ShotPattern", Data: tt.data})
+			if err != nil {
+				t.Fatalf("ProcessRawData failed: %v", err)
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("ProcessRawData() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
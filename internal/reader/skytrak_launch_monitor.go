@@ -0,0 +1,89 @@
+package reader
+
+import (
+	"albatross/internal/models"
+	"albatross/internal/processors"
+)
+
+// skyTrakDirectionalColumns lists the SkyTrak columns that may encode direction
+// with an "L" or "R" marker instead of a sign
+var skyTrakDirectionalColumns = []string{"offline", "side angle"}
+
+// skyTrakLaunchColumns names the SkyTrak export's launch data columns. SkyTrak reports
+// spin as backspin and sidespin components and the horizontal launch angle as "Side Angle".
+var skyTrakLaunchColumns = launchColumns{
+	BallSpeed:       "ball speed",
+	LaunchAngle:     "launch angle",
+	LaunchDirection: "side angle",
+	DescentAngle:    "descent angle",
+	Backspin:        "back spin",
+	Sidespin:        "side spin",
+	ClubSpeed:       "club speed",
+	SmashFactor:     "smash factor",
+}
+
+// SkyTrakLaunchMonitor implements the LaunchMonitor interface for SkyTrak session exports
+type SkyTrakLaunchMonitor struct{}
+
+// NewSkyTrakLaunchMonitor creates and returns a new SkyTrakLaunchMonitor instance
+func NewSkyTrakLaunchMonitor() models.LaunchMonitor {
+	return &SkyTrakLaunchMonitor{}
+}
+
+func init() {
+	Register(Registration{
+		Name:        "skytrak",
+		Aliases:     []string{"skytrak+"},
+		Description: "SkyTrak session CSV export",
+		Signature: Signature{
+			Columns: []string{"club", "carry", "total", "offline", "side angle", "back spin", "side spin"},
+			Units:   UnitsAssumed,
+		},
+		New: NewSkyTrakLaunchMonitor,
+	})
+}
+
+// ParseRow converts a row of strings into a RawShotData struct for SkyTrak data
+func (launchMonitor SkyTrakLaunchMonitor) ParseRow(row []string, headers []string) (models.RawShotData, error) {
+	data, err := mapRow(row, headers)
+	if err != nil {
+		return models.RawShotData{}, err
+	}
+
+	if err := signDirectionalColumns(data, skyTrakDirectionalColumns); err != nil {
+		return models.RawShotData{}, err
+	}
+
+	return models.RawShotData{
+		LaunchMonitorType: "SkyTrak",
+		Data:              data,
+	}, nil
+}
+
+// ProcessRawData converts RawShotData into ProcessedShotData for SkyTrak data.
+// SkyTrak reports the lateral landing position as "Offline".
+func (launchMonitor SkyTrakLaunchMonitor) ProcessRawData(rawData models.RawShotData) (models.ProcessedShotData, error) {
+	fields := shotFields{data: rawData.Data}
+	clubType := fields.text("club")
+	carryDistance := fields.optional("carry")
+	totalDistance := fields.number("total")
+	sideCarry := fields.number("offline")
+
+	normalizedClub := processors.NormalizeClubType(clubType)
+	shotType := processors.DetermineShotType(normalizedClub)
+
+	processed := models.ProcessedShotData{
+		Carry: carryDistance,
+		Club:  normalizedClub,
+		Roll:  rollDistance(totalDistance, carryDistance),
+		Type:  shotType,
+		Total: totalDistance,
+		Side:  sideCarry,
+	}
+	readLaunchData(&processed, &fields, skyTrakLaunchColumns)
+
+	if fields.err != nil {
+		return models.ProcessedShotData{}, fields.err
+	}
+	return processed, nil
+}
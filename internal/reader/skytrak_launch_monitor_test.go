@@ -0,0 +1,74 @@
+package reader
+
+import (
+	"reflect"
+	"testing"
+
+	"albatross/internal/models"
+)
+
+func TestSkyTrakLaunchMonitorParseRow(t *testing.T) {
+	launchMonitor := SkyTrakLaunchMonitor{}
+	headers := []string{"club", "carry", "total", "offline", "side angle"}
+	row := []string{"PW", "101.3", "108.0", "4.2 L", "-1.3"}
+
+	expected := models.RawShotData{
+		LaunchMonitorType: "SkyTrak",
+		Data: map[string]string{
+			"club":       "PW",
+			"carry":      "101.3",
+			"total":      "108.0",
+			"offline":    "-4.2",
+			"side angle": "-1.3",
+		},
+	}
+
+	result, err := launchMonitor.ParseRow(row, headers)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("ParseRow() = %v, want %v", result, expected)
+	}
+}
+
+func TestSkyTrakLaunchMonitorProcessRawData(t *testing.T) {
+	launchMonitor := SkyTrakLaunchMonitor{}
+	rawData := models.RawShotData{
+		LaunchMonitorType: "SkyTrak",
+		Data: map[string]string{
+			"club":         "PW",
+			"carry":        "101.5",
+			"total":        "108.0",
+			"offline":      "-4.2",
+			"ball speed":   "86.0",
+			"launch angle": "27.1",
+			"side angle":   "-1.8",
+			"back spin":    "8000",
+			"side spin":    "-600",
+		},
+	}
+
+	// SkyTrak only reports spin components, so the spin rate and axis are derived from them
+	expected := models.ProcessedShotData{
+		Carry:           101.5,
+		Club:            "Pw",
+		Roll:            6.5,
+		Type:            "Approach",
+		Total:           108,
+		Side:            -4.2,
+		BallSpeed:       86,
+		LaunchAngle:     27.1,
+		LaunchDirection: -1.8,
+		SpinRate:        8022,
+		SpinAxis:        -4.29,
+	}
+
+	result, err := launchMonitor.ProcessRawData(rawData)
+	if err != nil {
+		t.Fatalf("ProcessRawData failed: %v", err)
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("ProcessRawData() = %v, want %v", result, expected)
+	}
+}
@@ -0,0 +1,144 @@
+package reader
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"albatross/internal/models"
+	"albatross/internal/processors"
+)
+
+// trackmanLaunchColumns names Trackman's launch data measurements, keyed like the JSON
+// measurement names. Trackman reports the descent angle as "LandingAngle".
+var trackmanLaunchColumns = launchColumns{
+	BallSpeed:       "ballspeed",
+	LaunchAngle:     "launchangle",
+	LaunchDirection: "launchdirection",
+	DescentAngle:    "landingangle",
+	SpinRate:        "spinrate",
+	SpinAxis:        "spinaxis",
+	ClubSpeed:       "clubspeed",
+	SmashFactor:     "smashfactor",
+}
+
+// TrackmanLaunchMonitor implements the LaunchMonitor and DocumentParser interfaces
+// for Trackman JSON session reports
+type TrackmanLaunchMonitor struct{}
+
+// NewTrackmanLaunchMonitor creates and returns a new TrackmanLaunchMonitor instance
+func NewTrackmanLaunchMonitor() models.LaunchMonitor {
+	return &TrackmanLaunchMonitor{}
+}
+
+func init() {
+	Register(Registration{
+		Name:        "trackman",
+		Aliases:     []string{"tm"},
+		Description: "Trackman JSON session report",
+		Signature: Signature{
+			DocumentKeys: []string{"strokegroups"},
+			Units:        UnitsKnown,
+		},
+		New: NewTrackmanLaunchMonitor,
+	})
+}
+
+// trackmanReport mirrors the parts of a Trackman JSON report that hold shots.
+// Strokes are grouped by club, and each stroke carries its own Measurement object.
+type trackmanReport struct {
+	StrokeGroups []struct {
+		Club    string           `json:"Club"`
+		Strokes []trackmanStroke `json:"Strokes"`
+	} `json:"StrokeGroups"`
+}
+
+type trackmanStroke struct {
+	Club        string                 `json:"Club"`
+	Time        string                 `json:"Time"`
+	Measurement map[string]interface{} `json:"Measurement"`
+}
+
+// ParseDocument decodes a Trackman JSON report and flattens each stroke's
+// measurement into a RawShotData keyed by the lowercased measurement name
+func (launchMonitor TrackmanLaunchMonitor) ParseDocument(r io.Reader) ([]models.RawShotData, error) {
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+
+	var report trackmanReport
+	if err := decoder.Decode(&report); err != nil {
+		return nil, fmt.Errorf("decoding Trackman report: %w", err)
+	}
+
+	var rawShots []models.RawShotData
+	for _, group := range report.StrokeGroups {
+		for _, stroke := range group.Strokes {
+			if stroke.Measurement == nil {
+				continue
+			}
+
+			data := make(map[string]string)
+			for name, value := range stroke.Measurement {
+				switch v := value.(type) {
+				case json.Number:
+					data[strings.ToLower(name)] = v.String()
+				case string:
+					data[strings.ToLower(name)] = v
+				}
+			}
+
+			data["club"] = stroke.Club
+			if data["club"] == "" {
+				data["club"] = group.Club
+			}
+			data["time"] = stroke.Time
+
+			rawShots = append(rawShots, models.RawShotData{
+				LaunchMonitorType: "Trackman",
+				Data:              data,
+			})
+		}
+	}
+
+	return rawShots, nil
+}
+
+// ParseRow satisfies the LaunchMonitor interface. Trackman reports are JSON documents read
+// by ParseDocument, and the signature declares no CSV columns, so no row is ever handed to it.
+func (launchMonitor TrackmanLaunchMonitor) ParseRow(row []string, headers []string) (models.RawShotData, error) {
+	return models.RawShotData{}, fmt.Errorf("trackman reports are read as JSON documents, not CSV rows")
+}
+
+// ProcessRawData converts RawShotData into ProcessedShotData for Trackman data.
+// Trackman reports distances and the "MaxHeight" apex in meters and speeds in meters per
+// second, so they are converted to yards, feet and mph.
+func (launchMonitor TrackmanLaunchMonitor) ProcessRawData(rawData models.RawShotData) (models.ProcessedShotData, error) {
+	fields := shotFields{data: rawData.Data}
+	clubType := fields.text("club")
+	carryDistance := models.Meters.ToYards(fields.optional("carry"))
+	totalDistance := models.Meters.ToYards(fields.number("total"))
+	sideCarry := models.Meters.ToYards(fields.number("side"))
+	apex := models.Feet.FromYards(models.Meters.ToYards(fields.optional("maxheight")))
+
+	normalizedClub := processors.NormalizeClubType(clubType)
+	shotType := processors.DetermineShotType(normalizedClub)
+
+	processed := models.ProcessedShotData{
+		Apex:  apex,
+		Carry: carryDistance,
+		Club:  normalizedClub,
+		Roll:  rollDistance(totalDistance, carryDistance),
+		Type:  shotType,
+		Total: totalDistance,
+		Side:  sideCarry,
+	}
+	readLaunchData(&processed, &fields, trackmanLaunchColumns)
+	processed.BallSpeed = models.MetersPerSecond.ToMph(processed.BallSpeed)
+	processed.ClubSpeed = models.MetersPerSecond.ToMph(processed.ClubSpeed)
+
+	if fields.err != nil {
+		return models.ProcessedShotData{}, fields.err
+	}
+	return processed, nil
+}
@@ -0,0 +1,118 @@
+package reader
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"albatross/internal/models"
+)
+
+const trackmanTestReport = `{
+  "Kind": "RangeSession",
+  "StrokeGroups": [
+    {
+      "Club": "7Iron",
+      "Strokes": [
+        {
+          "Time": "2024-09-05T21:27:00Z",
+          "Club": "7Iron",
+          "Measurement": {"BallSpeed": 52.1, "LaunchAngle": 17.2, "SpinRate": 6512, "Carry": 140.2, "Total": 146.3, "Side": -2.5}
+        },
+        {
+          "Time": "2024-09-05T21:28:00Z",
+          "Measurement": {"BallSpeed": 53.0, "Carry": 142.0, "Total": 148.0, "Side": 1.0}
+        }
+      ]
+    },
+    {
+      "Club": "Driver",
+      "Strokes": [
+        {"Club": "Driver", "Measurement": {"Carry": 210.0, "Total": 228.6, "Side": 9.1}},
+        {"Club": "Driver"}
+      ]
+    }
+  ]
+}`
+
+func TestTrackmanLaunchMonitorParseDocument(t *testing.T) {
+	launchMonitor := TrackmanLaunchMonitor{}
+
+	rawShots, err := launchMonitor.ParseDocument(strings.NewReader(trackmanTestReport))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(rawShots) != 3 {
+		t.Fatalf("Expected 3 shots, got %d", len(rawShots))
+	}
+
+	first := rawShots[0]
+	if first.LaunchMonitorType != "Trackman" {
+		t.Errorf("Expected LaunchMonitorType to be 'Trackman', got %s", first.LaunchMonitorType)
+	}
+	if first.Data["club"] != "7Iron" || first.Data["total"] != "146.3" || first.Data["side"] != "-2.5" {
+		t.Errorf("Unexpected data for first shot: %v", first.Data)
+	}
+
+	// Strokes without their own club inherit the group's club
+	if rawShots[1].Data["club"] != "7Iron" {
+		t.Errorf("Expected second shot to inherit club '7Iron', got %q", rawShots[1].Data["club"])
+	}
+
+	if _, err := launchMonitor.ParseDocument(strings.NewReader("not json")); err == nil {
+		t.Errorf("Expected error for invalid JSON, got nil")
+	}
+}
+
+func TestTrackmanLaunchMonitorParseRow(t *testing.T) {
+	// Trackman reports are only read as JSON documents
+	launchMonitor := TrackmanLaunchMonitor{}
+	if _, err := launchMonitor.ParseRow([]string{"7Iron", "146.3", "-2.5"}, []string{"club", "total", "side"}); err == nil {
+		t.Errorf("Expected error for a CSV row, got nil")
+	}
+}
+
+func TestTrackmanLaunchMonitorProcessRawData(t *testing.T) {
+	launchMonitor := TrackmanLaunchMonitor{}
+	rawData := models.RawShotData{
+		LaunchMonitorType: "Trackman",
+		Data: map[string]string{
+			"club":        "7Iron",
+			"carry":       "137.2",
+			"total":       "146.3",
+			"side":        "-2.5",
+			"ballspeed":   "52.1",
+			"clubspeed":   "38.6",
+			"launchangle": "16.3",
+			"spinrate":    "6912",
+			"maxheight":   "28.1",
+		},
+	}
+
+	result, err := launchMonitor.ProcessRawData(rawData)
+	if err != nil {
+		t.Fatalf("ProcessRawData failed: %v", err)
+	}
+	if result.Club != "7i" || result.Type != "Approach" {
+		t.Errorf("Unexpected club or type: %+v", result)
+	}
+	if math.Abs(result.Total-159.996) > 0.01 {
+		t.Errorf("Expected Total to be converted to about 160.00 yards, got %.3f", result.Total)
+	}
+	if math.Abs(result.Side-(-2.734)) > 0.01 {
+		t.Errorf("Expected Side to be converted to about -2.73 yards, got %.3f", result.Side)
+	}
+	if math.Abs(result.Carry-150.044) > 0.01 || math.Abs(result.Roll-9.95) > 0.01 {
+		t.Errorf("Expected Carry and Roll in yards, got %.3f and %.3f", result.Carry, result.Roll)
+	}
+	if math.Abs(result.BallSpeed-116.544) > 0.01 || math.Abs(result.ClubSpeed-86.346) > 0.01 {
+		t.Errorf("Expected speeds to be converted to mph, got %.3f and %.3f", result.BallSpeed, result.ClubSpeed)
+	}
+	if math.Abs(result.Apex-92.192) > 0.01 {
+		t.Errorf("Expected Apex to be converted to about 92.19 feet, got %.3f", result.Apex)
+	}
+	if result.SmashFactor != 1.35 || result.LaunchAngle != 16.3 || result.SpinRate != 6912 {
+		t.Errorf("Unexpected launch data: %+v", result)
+	}
+}
@@ -0,0 +1,92 @@
+package reader
+
+import (
+	"regexp"
+	"strings"
+
+	"albatross/internal/models"
+	"albatross/internal/processors"
+)
+
+// headerUnitPattern matches a unit in brackets at the end of a header, such as "carry (m)" or "ball speed [km/h]"
+var headerUnitPattern = regexp.MustCompile(`^(.*?)\s*[(\[]\s*([^()\[\]]+?)\s*[)\]]$`)
+
+// ColumnUnit is the unit an export gives a column's values in: a distance unit, a speed unit or neither
+type ColumnUnit struct {
+	Distance models.DistanceUnit
+	Speed    models.SpeedUnit
+}
+
+// ParseColumnUnit reads a distance or speed unit label such as "m", "yds" or "km/h",
+// optionally in brackets as in the unit rows of Garmin exports (e.g. "[m]")
+func ParseColumnUnit(label string) (ColumnUnit, bool) {
+	label = strings.Trim(strings.TrimSpace(label), "[]()")
+	if unit, ok := models.ParseDistanceUnit(label); ok {
+		return ColumnUnit{Distance: unit}, true
+	}
+	if unit, ok := models.ParseSpeedUnit(label); ok {
+		return ColumnUnit{Speed: unit}, true
+	}
+	return ColumnUnit{}, false
+}
+
+// IsImperial reports whether values in the unit are already in the yards or mph readers expect
+func (unit ColumnUnit) IsImperial() bool {
+	return (unit.Distance == "" || unit.Distance == models.Yards) && (unit.Speed == "" || unit.Speed == models.MilesPerHour)
+}
+
+// ToImperial converts an exported value in the unit into yards or mph, keeping any "L" or "R"
+// direction marker. Values that hold no number, such as "-", are returned unchanged.
+func (unit ColumnUnit) ToImperial(value string) string {
+	var factor float64
+	switch {
+	case unit.Distance != "":
+		factor = unit.Distance.ToYards(1)
+	case unit.Speed != "":
+		factor = unit.Speed.ToMph(1)
+	default:
+		return value
+	}
+	converted, _ := processors.ScaleValue(value, factor)
+	return converted
+}
+
+// ToFeet converts an exported height in the unit's distance unit into feet, the unit apex
+// heights are read in. Values that hold no number, or aren't in a distance unit, are returned unchanged.
+func (unit ColumnUnit) ToFeet(value string) string {
+	if unit.Distance == "" {
+		return value
+	}
+	converted, _ := processors.ScaleValue(value, models.Feet.FromYards(unit.Distance.ToYards(1)))
+	return converted
+}
+
+// SplitHeaderUnit splits a header such as "carry (m)" into its name and the distance or speed
+// unit it ends in. It reports false for headers without one, including other units like "(rpm)".
+func SplitHeaderUnit(header string) (string, ColumnUnit, bool) {
+	match := headerUnitPattern.FindStringSubmatch(header)
+	if match == nil {
+		return header, ColumnUnit{}, false
+	}
+	unit, ok := ParseColumnUnit(match[2])
+	if !ok {
+		return header, ColumnUnit{}, false
+	}
+	return match[1], unit, true
+}
+
+// NormalizeHeader lowercases and trims a header and replaces a distance or speed unit at its end
+// with the yards or mph values are converted into, so "Carry (m)" reads as "carry (yds)" and
+// "Ball (km/h)" as "ball (mph)" and readers find the same columns whatever units were exported
+func NormalizeHeader(header string) string {
+	header = strings.ToLower(strings.TrimSpace(header))
+	name, unit, ok := SplitHeaderUnit(header)
+	switch {
+	case !ok:
+		return header
+	case unit.Distance != "":
+		return name + " (yds)"
+	default:
+		return name + " (mph)"
+	}
+}
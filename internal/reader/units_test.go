@@ -0,0 +1,85 @@
+package reader
+
+import (
+	"testing"
+
+	"albatross/internal/models"
+)
+
+func TestNormalizeHeader(t *testing.T) {
+	tests := []struct {
+		header   string
+		expected string
+	}{
+		{" Carry (m) ", "carry (yds)"},
+		{"Carry (yds)", "carry (yds)"},
+		{"Total [Meters]", "total (yds)"},
+		{"Ball (km/h)", "ball (mph)"},
+		{"Club (mph)", "club (mph)"},
+		{"Spin (rpm)", "spin (rpm)"},
+		{"Club", "club"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			if result := NormalizeHeader(tt.header); result != tt.expected {
+				t.Errorf("NormalizeHeader(%q) = %q, want %q", tt.header, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSplitHeaderUnit(t *testing.T) {
+	name, unit, ok := SplitHeaderUnit("lateral (m)")
+	if !ok || name != "lateral" || unit != (ColumnUnit{Distance: models.Meters}) {
+		t.Errorf("SplitHeaderUnit() = %q, %+v, %v", name, unit, ok)
+	}
+	if _, _, ok := SplitHeaderUnit("spin (rpm)"); ok {
+		t.Errorf("Expected no distance or speed unit in 'spin (rpm)'")
+	}
+}
+
+func TestColumnUnitToImperial(t *testing.T) {
+	tests := []struct {
+		name     string
+		unit     ColumnUnit
+		value    string
+		expected string
+	}{
+		{"Meters", ColumnUnit{Distance: models.Meters}, "141.2", "154.42"},
+		{"Directional meters", ColumnUnit{Distance: models.Meters}, "5.2 L", "5.69 L"},
+		{"Kilometers per hour", ColumnUnit{Speed: models.KilometersPerHour}, "180", "111.85"},
+		{"Missing value", ColumnUnit{Distance: models.Meters}, "-", "-"},
+		{"No unit", ColumnUnit{}, "12.5", "12.5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.unit.ToImperial(tt.value); result != tt.expected {
+				t.Errorf("ToImperial(%q) = %q, want %q", tt.value, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestColumnUnitToFeet(t *testing.T) {
+	tests := []struct {
+		name     string
+		unit     ColumnUnit
+		value    string
+		expected string
+	}{
+		{"Meters", ColumnUnit{Distance: models.Meters}, "14.45", "47.41"},
+		{"Yards", ColumnUnit{Distance: models.Yards}, "15.8", "47.4"},
+		{"Missing value", ColumnUnit{Distance: models.Meters}, "-", "-"},
+		{"Speed", ColumnUnit{Speed: models.KilometersPerHour}, "180", "180"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.unit.ToFeet(tt.value); result != tt.expected {
+				t.Errorf("ToFeet(%q) = %q, want %q", tt.value, result, tt.expected)
+			}
+		})
+	}
+}
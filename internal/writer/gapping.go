@@ -0,0 +1,69 @@
+package writer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"albatross/internal/models"
+)
+
+// GappingFormats lists the supported gapping report format names
+var GappingFormats = []string{"text", "json"}
+
+// WriteGapping writes a bag's gapping in a gapping report format: "text" for a table with
+// a row per club from the least lofted to the most, or "json" for the gapping as a JSON
+// document. Format names are case-insensitive.
+func WriteGapping(output io.Writer, gapping models.Gapping, format string) error {
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case "text":
+		return writeGappingTable(output, gapping)
+	case "json":
+		encoder := json.NewEncoder(output)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(gapping); err != nil {
+			return fmt.Errorf("writing gapping: %w", err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported gapping format: %s", format)
+	}
+}
+
+// writeGappingTable writes a table of the clubs' targets, each with its gap to the next
+// longer club and how much their distances overlap, noting holes and overlaps
+func writeGappingTable(output io.Writer, gapping models.Gapping) error {
+	table := tabwriter.NewWriter(output, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintf(table, "CLUB\tLOFT\tSHOTS\tCARRY\tTOTAL\t%s GAP\tOVERLAP\tNOTE\t\n", strings.ToUpper(gapping.Distance))
+	for i, club := range gapping.Clubs {
+		loft, carry := "–", "–"
+		if club.Loft > 0 {
+			loft = fmt.Sprintf("%g°", club.Loft)
+		}
+		if club.Carry > 0 {
+			carry = fmt.Sprintf("%.1f", club.Carry)
+		}
+		fmt.Fprintf(table, "%s\t%s\t%d\t%s\t%.1f\t", club.Club, loft, club.Shots, carry, club.Total)
+
+		if i == 0 {
+			fmt.Fprint(table, "\t\t\t\n")
+			continue
+		}
+		gap := gapping.Gaps[i-1]
+		judged := gap.TotalGap
+		if gapping.Distance == "carry" {
+			judged = gap.CarryGap
+		}
+		var notes []string
+		if gap.Hole {
+			notes = append(notes, "hole")
+		}
+		if gap.Overlapping {
+			notes = append(notes, "overlap")
+		}
+		fmt.Fprintf(table, "%.1f\t%.0f%%\t%s\t\n", judged, gap.Overlap*100, strings.Join(notes, ", "))
+	}
+	return table.Flush()
+}
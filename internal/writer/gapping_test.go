@@ -0,0 +1,69 @@
+package writer
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"albatross/internal/models"
+)
+
+var gappingTestData = models.Gapping{
+	Distance: "carry",
+	Clubs: []models.GappingClub{
+		{Club: "Dr", Loft: 10.5, Shots: 9, Carry: 225, Total: 245},
+		{Club: "3W", Loft: 15, Shots: 6, Carry: 212, Total: 232},
+		{Club: "5i", Loft: 26, Shots: 6, Carry: 210, Total: 225},
+		{Club: "7i", Loft: 34, Shots: 6, Carry: 150, Total: 160},
+	},
+	Gaps: []models.ClubGap{
+		{Longer: "Dr", Shorter: "3W", CarryGap: 13, TotalGap: 13},
+		{Longer: "3W", Shorter: "5i", CarryGap: 2, TotalGap: 7, Overlap: 0.4, Overlapping: true},
+		{Longer: "5i", Shorter: "7i", CarryGap: 60, TotalGap: 65, Hole: true},
+	},
+}
+
+func TestWriteGappingText(t *testing.T) {
+	var buffer bytes.Buffer
+	if err := WriteGapping(&buffer, gappingTestData, "Text"); err != nil {
+		t.Fatalf("WriteGapping failed: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimRight(buffer.String(), "\n"), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("Expected a header and 4 clubs, got:\n%s", buffer.String())
+	}
+	expectedFields := [][]string{
+		{"CLUB", "LOFT", "SHOTS", "CARRY", "TOTAL", "CARRY", "GAP", "OVERLAP", "NOTE"},
+		{"Dr", "10.5°", "9", "225.0", "245.0"},
+		{"3W", "15°", "6", "212.0", "232.0", "13.0", "0%"},
+		{"5i", "26°", "6", "210.0", "225.0", "2.0", "40%", "overlap"},
+		{"7i", "34°", "6", "150.0", "160.0", "60.0", "0%", "hole"},
+	}
+	for i, line := range lines {
+		if fields := strings.Fields(line); !reflect.DeepEqual(fields, expectedFields[i]) {
+			t.Errorf("Line %d = %q, want fields %q", i, line, expectedFields[i])
+		}
+	}
+}
+
+func TestWriteGappingJSON(t *testing.T) {
+	var buffer bytes.Buffer
+	if err := WriteGapping(&buffer, gappingTestData, "json"); err != nil {
+		t.Fatalf("WriteGapping failed: %v", err)
+	}
+
+	var gapping models.Gapping
+	if err := json.Unmarshal(buffer.Bytes(), &gapping); err != nil {
+		t.Fatalf("Output is not valid JSON: %v\n%s", err, buffer.String())
+	}
+	if !reflect.DeepEqual(gapping, gappingTestData) {
+		t.Errorf("Gapping = %+v, want %+v", gapping, gappingTestData)
+	}
+
+	if err := WriteGapping(&buffer, gappingTestData, "xml"); err == nil {
+		t.Errorf("Expected error for an unsupported format, got nil")
+	}
+}
@@ -0,0 +1,312 @@
+package writer
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"math"
+	"strings"
+	"time"
+
+	"albatross/internal/calculators"
+	"albatross/internal/models"
+)
+
+// Dimensions of each club's dispersion plot, in SVG user units
+const (
+	plotWidth   = 320.0
+	plotHeight  = 320.0
+	plotPadding = 36.0
+)
+
+// ellipseSegments is the number of straight segments a dispersion ellipse is drawn with
+const ellipseSegments = 48
+
+// htmlReport is the data rendered by the HTML report template
+type htmlReport struct {
+	Metadata
+	GeneratedAt  time.Time
+	ShotCount    int
+	DistanceUnit models.DistanceUnit // The unit the plots' axes are in, if the metadata gives its units
+	Clubs        []htmlClub
+}
+
+// htmlClub is a club's summary and dispersion along with its dispersion plot
+type htmlClub struct {
+	models.ClubSummary
+	Dispersion models.ClubDispersion
+	Plot       htmlPlot
+}
+
+// htmlPlot is a club's dispersion plot with every coordinate already in SVG user units
+type htmlPlot struct {
+	Width, Height float64
+	Left, Right   float64 // X coordinates of the plot area's edges
+	Top, Bottom   float64 // Y coordinates of the plot area's edges
+	CenterX       float64 // X coordinate of the target line (zero side carry)
+	TargetY       float64 // Y coordinate of the club's target distance
+	ShowTarget    bool    // Whether the target is a total distance, so lines up with the plotted shots
+	Points        []htmlPoint
+	Ellipses      []htmlEllipse
+	MinTotal      float64 // Total distance at the bottom edge
+	MaxTotal      float64 // Total distance at the top edge
+	MaxSide       float64 // Side carry at the left and right edges
+}
+
+// htmlPoint is a shot's position in a dispersion plot
+type htmlPoint struct {
+	X, Y    float64
+	Carry   float64
+	Total   float64
+	Side    float64
+	Flags   []string // Why the shot looks like a misread, if it does
+	Outlier string   // Why the shot is an outlier, if it is
+}
+
+// htmlEllipse is a dispersion ellipse in a dispersion plot
+type htmlEllipse struct {
+	Sigma  float64
+	Points string // The SVG polygon points outlining the ellipse
+}
+
+// HTMLWriter writes a self-contained HTML report of the session, with a summary
+// table and an SVG dispersion plot of total against side carry with 1σ and 2σ ellipses for each club.
+// The report has no external dependencies, so it can be emailed and viewed offline.
+type HTMLWriter struct {
+	Metadata Metadata
+}
+
+func (w HTMLWriter) Write(filename string, data []models.ProcessedShotData) error {
+	return writeFile(filename, data, w)
+}
+
+func (w HTMLWriter) WriteStream(output io.Writer, data []models.ProcessedShotData) error {
+	if len(data) == 0 {
+		return fmt.Errorf("no data to write")
+	}
+
+	report := htmlReport{
+		Metadata:    w.Metadata,
+		GeneratedAt: time.Now(),
+		ShotCount:   len(data),
+	}
+	report.DistanceUnit, _, _ = models.SystemUnits(w.Metadata.Units)
+	// Summaries and dispersions both list the clubs in the order they first appear
+	dispersions := calculators.CalculateDispersions(data)
+	for i, summary := range calculators.SummarizeClubs(data) {
+		report.Clubs = append(report.Clubs, htmlClub{
+			ClubSummary: summary,
+			Dispersion:  dispersions[i],
+			Plot:        newHTMLPlot(summary, dispersions[i], data),
+		})
+	}
+
+	if err := htmlReportTemplate.Execute(output, report); err != nil {
+		return fmt.Errorf("writing report: %w", err)
+	}
+	return nil
+}
+
+// newHTMLPlot lays out a club's shots and dispersion ellipses with total distance running
+// up the plot and side carry across it, centred on the target line. The club's target is
+// only drawn when it was calculated from total distance; a carry target would sit short
+// of the shots it was calculated from.
+func newHTMLPlot(summary models.ClubSummary, dispersion models.ClubDispersion, data []models.ProcessedShotData) htmlPlot {
+	var shots []models.ProcessedShotData
+	for _, shot := range data {
+		if shot.Club == summary.Club {
+			shots = append(shots, shot)
+		}
+	}
+
+	// Targets without a distance were calculated from total, as ShotPattern files are written
+	showTarget := summary.TargetDistance != "carry"
+	minTotal, maxTotal := math.Inf(1), math.Inf(-1)
+	if showTarget {
+		minTotal, maxTotal = summary.Target, summary.Target
+	}
+	maxSide := 10.0 // Show at least ten yards either side of the target line
+	for _, shot := range shots {
+		minTotal = math.Min(minTotal, shot.Total)
+		maxTotal = math.Max(maxTotal, shot.Total)
+		maxSide = math.Max(maxSide, math.Abs(shot.Side)*1.2)
+	}
+	outlines := make([][][2]float64, len(dispersion.Ellipses))
+	for i, ellipse := range dispersion.Ellipses {
+		outlines[i] = ellipseOutline(ellipse)
+		for _, point := range outlines[i] {
+			minTotal = math.Min(minTotal, point[0])
+			maxTotal = math.Max(maxTotal, point[0])
+			maxSide = math.Max(maxSide, math.Abs(point[1]))
+		}
+	}
+	margin := math.Max(5, (maxTotal-minTotal)*0.1)
+	minTotal, maxTotal = math.Floor(minTotal-margin), math.Ceil(maxTotal+margin)
+	maxSide = math.Ceil(maxSide)
+
+	plot := htmlPlot{
+		Width:    plotWidth,
+		Height:   plotHeight,
+		Left:     plotPadding,
+		Right:    plotWidth - plotPadding/2,
+		Top:      plotPadding / 2,
+		Bottom:   plotHeight - plotPadding,
+		MinTotal: minTotal,
+		MaxTotal: maxTotal,
+		MaxSide:  maxSide,
+	}
+	plot.CenterX = plot.x(0)
+	if showTarget {
+		plot.ShowTarget = true
+		plot.TargetY = plot.y(summary.Target)
+	}
+	for i, ellipse := range dispersion.Ellipses {
+		points := make([]string, len(outlines[i]))
+		for j, point := range outlines[i] {
+			points[j] = fmt.Sprintf("%.2f,%.2f", plot.x(point[1]), plot.y(point[0]))
+		}
+		plot.Ellipses = append(plot.Ellipses, htmlEllipse{Sigma: ellipse.Sigma, Points: strings.Join(points, " ")})
+	}
+	for _, shot := range shots {
+		plot.Points = append(plot.Points, htmlPoint{X: plot.x(shot.Side), Y: plot.y(shot.Total), Carry: shot.Carry, Total: shot.Total, Side: shot.Side, Flags: shot.Flags, Outlier: shot.Outlier})
+	}
+	return plot
+}
+
+// ellipseOutline returns points around a dispersion ellipse as total distance and side carry pairs
+func ellipseOutline(ellipse models.DispersionEllipse) [][2]float64 {
+	angle := ellipse.Angle * math.Pi / 180
+	outline := make([][2]float64, ellipseSegments)
+	for i := range outline {
+		t := 2 * math.Pi * float64(i) / ellipseSegments
+		major, minor := ellipse.SemiMajor*math.Cos(t), ellipse.SemiMinor*math.Sin(t)
+		outline[i] = [2]float64{
+			ellipse.CenterTotal + major*math.Cos(angle) - minor*math.Sin(angle),
+			ellipse.CenterSide + major*math.Sin(angle) + minor*math.Cos(angle),
+		}
+	}
+	return outline
+}
+
+// x maps a side carry onto the plot's horizontal axis
+func (plot htmlPlot) x(side float64) float64 {
+	return plot.Left + (side+plot.MaxSide)/(2*plot.MaxSide)*(plot.Right-plot.Left)
+}
+
+// MiddleY is the Y coordinate halfway down the plot area, where the total axis is labelled
+func (plot htmlPlot) MiddleY() float64 {
+	return (plot.Top + plot.Bottom) / 2
+}
+
+// y maps a total distance onto the plot's vertical axis, further shots higher up
+func (plot htmlPlot) y(total float64) float64 {
+	return plot.Bottom - (total-plot.MinTotal)/(plot.MaxTotal-plot.MinTotal)*(plot.Bottom-plot.Top)
+}
+
+var htmlReportTemplate = template.Must(template.New("report").Funcs(template.FuncMap{"sqrt": math.Sqrt}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Albatross session report{{with .Player}} - {{.}}{{end}}{{with .Source}} - {{.}}{{end}}</title>
+<style>
+  body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2em; color: #1d2b1f; }
+  h1 { font-size: 1.5em; margin-bottom: 0.2em; }
+  .meta { color: #5b6b5d; margin-top: 0; }
+  table { border-collapse: collapse; margin: 1.5em 0; }
+  th, td { padding: 0.35em 0.9em; border-bottom: 1px solid #d8e0d9; text-align: right; }
+  th:first-child, td:first-child, th:nth-child(2), td:nth-child(2) { text-align: left; }
+  th { background: #eef3ef; }
+  .plots { display: flex; flex-wrap: wrap; gap: 1.5em; }
+  figure { margin: 0; }
+  figcaption { font-weight: bold; text-align: center; }
+  svg { background: #f6faf6; border: 1px solid #d8e0d9; }
+  .axis { stroke: #9aa89c; stroke-width: 1; }
+  .centerline { stroke: #9aa89c; stroke-dasharray: 4 4; }
+  .target { stroke: #c0392b; stroke-width: 2; }
+  .ellipse { fill: #2e7d32; fill-opacity: 0.08; stroke: #2e7d32; stroke-opacity: 0.5; }
+  .shot { fill: #2e7d32; fill-opacity: 0.75; }
+  .shot.outlier { fill: #e67e22; }
+  .shot.flagged { fill: none; stroke: #c0392b; stroke-width: 1.5; }
+  .label { font-size: 11px; fill: #5b6b5d; }
+</style>
+</head>
+<body>
+<h1>Albatross session report</h1>
+<p class="meta">{{with .Player}}{{.}} &middot; {{end}}{{with .StartTime}}{{.Format "2 Jan 2006 15:04"}} &middot; {{end}}{{with .Device}}{{.}} &middot; {{end}}{{with .Source}}{{.}} &middot; {{end}}{{with .LaunchMonitorType}}{{.}} &middot; {{end}}{{with .TargetStrategy}}{{.}} targets &middot; {{end}}{{with .OutlierDetector}}{{.}} outliers{{if $.ExcludeOutliers}} excluded{{end}} &middot; {{end}}{{.ShotCount}} shots &middot; generated {{.GeneratedAt.Format "2006-01-02 15:04"}}</p>
+
+<table>
+  <thead>
+    <tr><th>Club</th><th>Type</th><th>Shots</th><th>Flagged</th><th>Outliers</th><th>Target</th><th>Target from</th><th>Mean carry</th><th>Median carry</th><th>Mean total</th><th>Median total</th><th>Side SD</th><th>Ball speed</th><th>Club speed</th><th>Smash</th><th>Launch</th><th>Spin</th></tr>
+  </thead>
+  <tbody>
+  {{- range .Clubs}}
+    <tr>
+      <td>{{.Club}}</td><td>{{.Type}}</td><td>{{.Shots}}</td><td>{{if .Flagged}}{{.Flagged}}{{else}}&ndash;{{end}}</td><td>{{if .Outliers}}{{.Outliers}}{{else}}&ndash;{{end}}</td><td>{{printf "%.1f" .Target}}</td><td>{{with .TargetDistance}}{{.}}{{else}}&ndash;{{end}}</td>
+      <td>{{if .MeanCarry}}{{printf "%.1f" .MeanCarry}}{{else}}&ndash;{{end}}</td>
+      <td>{{if .MedianCarry}}{{printf "%.1f" .MedianCarry}}{{else}}&ndash;{{end}}</td>
+      <td>{{printf "%.1f" .MeanTotal}}</td><td>{{printf "%.1f" .MedianTotal}}</td><td>{{printf "%.1f" .SideStdDev}}</td>
+      <td>{{if .MeanBallSpeed}}{{printf "%.1f" .MeanBallSpeed}}{{else}}&ndash;{{end}}</td>
+      <td>{{if .MeanClubSpeed}}{{printf "%.1f" .MeanClubSpeed}}{{else}}&ndash;{{end}}</td>
+      <td>{{if .MeanSmashFactor}}{{printf "%.2f" .MeanSmashFactor}}{{else}}&ndash;{{end}}</td>
+      <td>{{if .MeanLaunchAngle}}{{printf "%.1f" .MeanLaunchAngle}}{{else}}&ndash;{{end}}</td>
+      <td>{{if .MeanSpinRate}}{{printf "%.0f" .MeanSpinRate}}{{else}}&ndash;{{end}}</td>
+    </tr>
+  {{- end}}
+  </tbody>
+</table>
+
+<table>
+  <thead>
+    <tr><th>Club</th><th>Shots</th><th>Mean total</th><th>Mean side</th><th>Total SD</th><th>Side SD</th><th>Covariance</th><th>1&sigma; ellipse</th><th>2&sigma; ellipse</th><th>Angle</th></tr>
+  </thead>
+  <tbody>
+  {{- range .Clubs}}
+  {{- with .Dispersion}}
+    <tr>
+      <td>{{.Club}}</td><td>{{.Shots}}</td><td>{{printf "%.1f" .MeanTotal}}</td><td>{{printf "%.1f" .MeanSide}}</td>
+      <td>{{printf "%.1f" (sqrt .TotalVariance)}}</td><td>{{printf "%.1f" (sqrt .SideVariance)}}</td><td>{{printf "%.1f" .Covariance}}</td>
+      {{- range .Ellipses}}
+      <td>{{printf "%.1f" .SemiMajor}} &times; {{printf "%.1f" .SemiMinor}}</td>
+      {{- else}}
+      <td>&ndash;</td><td>&ndash;</td>
+      {{- end}}
+      <td>{{with .Ellipses}}{{printf "%.0f" (index . 0).Angle}}&deg;{{else}}&ndash;{{end}}</td>
+    </tr>
+  {{- end}}
+  {{- end}}
+  </tbody>
+</table>
+
+<div class="plots">
+{{- range .Clubs}}
+{{- $club := .}}
+{{- with .Plot}}
+<figure>
+  <figcaption>{{$club.Club}} &middot; target {{printf "%.1f" $club.Target}}{{with $club.TargetDistance}} {{.}}{{end}}</figcaption>
+  <svg xmlns="http://www.w3.org/2000/svg" width="{{.Width}}" height="{{.Height}}" viewBox="0 0 {{.Width}} {{.Height}}" role="img" aria-label="{{$club.Club}} dispersion">
+    <line class="axis" x1="{{.Left}}" y1="{{.Bottom}}" x2="{{.Right}}" y2="{{.Bottom}}"/>
+    <line class="axis" x1="{{.Left}}" y1="{{.Top}}" x2="{{.Left}}" y2="{{.Bottom}}"/>
+    <line class="centerline" x1="{{.CenterX}}" y1="{{.Top}}" x2="{{.CenterX}}" y2="{{.Bottom}}"/>
+    {{- range .Ellipses}}
+    <polygon class="ellipse" points="{{.Points}}"><title>{{printf "%.0f" .Sigma}}&sigma; dispersion</title></polygon>
+    {{- end}}
+    {{- if .ShowTarget}}
+    <line class="target" x1="{{.Left}}" y1="{{printf "%.2f" .TargetY}}" x2="{{.Right}}" y2="{{printf "%.2f" .TargetY}}"/>
+    {{- end}}
+    {{- range .Points}}
+    <circle class="shot{{if .Outlier}} outlier{{end}}{{if .Flags}} flagged{{end}}" cx="{{printf "%.2f" .X}}" cy="{{printf "%.2f" .Y}}" r="4"><title>{{with .Carry}}{{printf "%.1f" .}} carry, {{end}}{{printf "%.1f" .Total}} total, {{printf "%.1f" .Side}} side{{with .Flags}}; likely misread: {{range $i, $flag := .}}{{if $i}}, {{end}}{{$flag}}{{end}}{{end}}{{with .Outlier}}; outlier: {{.}}{{end}}</title></circle>
+    {{- end}}
+    <text class="label" x="{{.Left}}" y="{{.Height}}" dy="-20">{{printf "%.0f" .MaxSide}} L</text>
+    <text class="label" x="{{.Right}}" y="{{.Height}}" dy="-20" text-anchor="end">{{printf "%.0f" .MaxSide}} R</text>
+    <text class="label" x="{{.Left}}" y="{{.Top}}" dx="-4" dy="4" text-anchor="end">{{printf "%.0f" .MaxTotal}}</text>
+    <text class="label" x="{{.Left}}" y="{{.Bottom}}" dx="-4" text-anchor="end">{{printf "%.0f" .MinTotal}}</text>
+    <text class="label" x="{{.CenterX}}" y="{{.Height}}" dy="-6" text-anchor="middle">Side{{with $.DistanceUnit}} ({{.}}){{end}}</text>
+    <text class="label" transform="translate(12 {{printf "%.2f" .MiddleY}}) rotate(-90)" text-anchor="middle">Total{{with $.DistanceUnit}} ({{.}}){{end}}</text>
+  </svg>
+</figure>
+{{- end}}
+{{- end}}
+</div>
+</body>
+</html>
+`))
@@ -0,0 +1,154 @@
+package writer
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"albatross/internal/models"
+)
+
+func TestHTMLWriter(t *testing.T) {
+	testFile := filepath.Join(t.TempDir(), "report.html")
+	startTime := time.Date(2024, 9, 5, 21, 27, 0, 0, time.UTC)
+	writer := HTMLWriter{Metadata: Metadata{Session: models.Session{
+		Device:            "Rapsodo MLM2PRO",
+		Player:            "Palmer Little",
+		StartTime:         &startTime,
+		Source:            "<session>.csv",
+		LaunchMonitorType: "mlm2pro",
+		Units:             models.UnitsImperial,
+	}}}
+
+	testData := []models.ProcessedShotData{
+		{Club: "Dr", Type: "Tee", Target: 255, Total: 250, Side: 5, Outlier: "total 250.0 below IQR fence 252.0"},
+		{Club: "Dr", Type: "Tee", Target: 255, Total: 260, Side: -3, Flags: []string{"zero spin", "smash factor 1.62 above 1.55"}},
+		{Club: "7i", Type: "Approach", Target: 141, TargetDistance: "carry", Carry: 141, Total: 150, Side: -2, BallSpeed: 112.4, ClubSpeed: 84, SmashFactor: 1.34, LaunchAngle: 17.2, SpinRate: 6512},
+	}
+
+	if err := writer.Write(testFile, testData); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	content, err := os.ReadFile(testFile)
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+	report := string(content)
+
+	expectedFragments := []string{
+		"<!DOCTYPE html>",
+		"&lt;session&gt;.csv",
+		"Palmer Little &middot; 5 Sep 2024 21:27 &middot; Rapsodo MLM2PRO",
+		"<td>Dr</td><td>Tee</td><td>2</td><td>1</td><td>1</td><td>255.0</td>",
+		"<td>7i</td><td>Approach</td><td>1</td><td>&ndash;</td><td>&ndash;</td><td>141.0</td><td>carry</td>",
+		"7i &middot; target 141.0 carry</figcaption>",
+		"<td>141.0</td>",
+		"<td>112.4</td>",
+		"<td>1.34</td>",
+		"<td>6512</td>",
+		"<th>1&sigma; ellipse</th>",
+		"<td>Dr</td><td>2</td><td>255.0</td><td>1.0</td>",
+		`aria-label="Dr dispersion"`,
+		`aria-label="7i dispersion"`,
+		`<line class="target"`,
+		"Side (yards)</text>",
+		"Total (yards)</text>",
+		`<circle class="shot flagged"`,
+		`<circle class="shot outlier"`,
+		"<title>250.0 total, 5.0 side; outlier: total 250.0 below IQR fence 252.0</title>",
+		"<title>260.0 total, -3.0 side; likely misread: zero spin, smash factor 1.62 above 1.55</title>",
+		"<title>141.0 carry, 150.0 total, -2.0 side</title>",
+	}
+	for _, fragment := range expectedFragments {
+		if !strings.Contains(report, fragment) {
+			t.Errorf("Report is missing %q", fragment)
+		}
+	}
+
+	// Only the Dr's total target is drawn, as the 7i's carry target would sit short of its shots
+	if count := strings.Count(report, `<line class="target"`); count != 1 {
+		t.Errorf("Expected 1 target line, got %d", count)
+	}
+	if count := strings.Count(report, `<circle class="shot`); count != len(testData) {
+		t.Errorf("Expected %d plotted shots, got %d", len(testData), count)
+	}
+	if strings.Contains(report, "<session>") {
+		t.Errorf("Report contains unescaped metadata")
+	}
+	if strings.Contains(report, "http://") && !strings.Contains(report, `xmlns="http://www.w3.org/2000/svg"`) {
+		t.Errorf("Report references external resources")
+	}
+
+	if err := writer.Write(testFile, []models.ProcessedShotData{}); err == nil {
+		t.Fatalf("Expected error for empty data, got nil")
+	}
+}
+
+func TestNewHTMLPlot(t *testing.T) {
+	summary := models.ClubSummary{Club: "7i", Target: 150}
+	data := []models.ProcessedShotData{
+		{Club: "7i", Total: 140, Side: -20},
+		{Club: "7i", Total: 160, Side: 0},
+		{Club: "Dr", Total: 260, Side: 40},
+	}
+
+	plot := newHTMLPlot(summary, models.ClubDispersion{Club: "7i"}, data)
+
+	if len(plot.Points) != 2 {
+		t.Fatalf("Expected only the club's 2 shots to be plotted, got %d", len(plot.Points))
+	}
+	if plot.MaxSide != 24 || plot.MinTotal != 135 || plot.MaxTotal != 165 {
+		t.Errorf("Unexpected plot ranges: side %v, total %v-%v", plot.MaxSide, plot.MinTotal, plot.MaxTotal)
+	}
+
+	// The target sits halfway up the plot and a shot on the target line is centred
+	if math.Abs(plot.TargetY-(plot.Top+plot.Bottom)/2) > 1e-9 {
+		t.Errorf("TargetY = %v, want midpoint of %v and %v", plot.TargetY, plot.Top, plot.Bottom)
+	}
+	if plot.Points[1].X != plot.CenterX {
+		t.Errorf("Shot with no side carry at x %v, want centre %v", plot.Points[1].X, plot.CenterX)
+	}
+	if plot.Points[0].X >= plot.CenterX || plot.Points[0].Y <= plot.Points[1].Y {
+		t.Errorf("Expected the shorter shot left of centre and lower down, got %+v", plot.Points[0])
+	}
+}
+
+func TestNewHTMLPlotCarryTarget(t *testing.T) {
+	summary := models.ClubSummary{Club: "7i", Target: 100, TargetDistance: "carry"}
+	data := []models.ProcessedShotData{
+		{Club: "7i", Carry: 100, Total: 140, Side: 0},
+		{Club: "7i", Carry: 110, Total: 160, Side: 0},
+	}
+
+	plot := newHTMLPlot(summary, models.ClubDispersion{Club: "7i"}, data)
+
+	// The carry target isn't drawn, so the plot only spans the shots' totals
+	if plot.ShowTarget {
+		t.Errorf("Expected a carry target not to be drawn")
+	}
+	if plot.MinTotal != 135 || plot.MaxTotal != 165 {
+		t.Errorf("Unexpected plot range: total %v-%v", plot.MinTotal, plot.MaxTotal)
+	}
+}
+
+func TestNewHTMLPlotEllipses(t *testing.T) {
+	summary := models.ClubSummary{Club: "7i", Target: 150}
+	dispersion := models.ClubDispersion{Club: "7i", Ellipses: []models.DispersionEllipse{
+		{Sigma: 2, CenterTotal: 150, SemiMajor: 20, SemiMinor: 15, Angle: 90},
+	}}
+	data := []models.ProcessedShotData{{Club: "7i", Total: 150, Side: 0}}
+
+	plot := newHTMLPlot(summary, dispersion, data)
+
+	if len(plot.Ellipses) != 1 || len(strings.Fields(plot.Ellipses[0].Points)) != ellipseSegments {
+		t.Fatalf("Expected one ellipse of %d points, got %+v", ellipseSegments, plot.Ellipses)
+	}
+	// The ellipse lies across the target line, so the plot widens to fit it rather than lengthens
+	if plot.MaxSide != 20 || plot.MinTotal != 130 || plot.MaxTotal != 170 {
+		t.Errorf("Unexpected plot ranges: side %v, total %v-%v", plot.MaxSide, plot.MinTotal, plot.MaxTotal)
+	}
+}
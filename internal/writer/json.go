@@ -0,0 +1,86 @@
+package writer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"albatross/internal/calculators"
+	"albatross/internal/models"
+)
+
+// jsonSession is the session metadata recorded at the top of the JSON output
+type jsonSession struct {
+	Metadata
+	GeneratedAt time.Time `json:"generatedAt"`
+	ShotCount   int       `json:"shotCount"`
+	ClubCount   int       `json:"clubCount"`
+}
+
+// jsonDocument is the layout of the JSON output: session metadata, per-club summaries and
+// dispersions and every shot
+type jsonDocument struct {
+	Session    jsonSession                `json:"session"`
+	Clubs      []models.ClubSummary       `json:"clubs"`
+	Dispersion []models.ClubDispersion    `json:"dispersion"`
+	Shots      []models.ProcessedShotData `json:"shots"`
+}
+
+// JSONWriter writes the processed shot data as a single JSON document
+// with session metadata and a summary and dispersion of each club
+type JSONWriter struct {
+	Metadata Metadata
+}
+
+func (w JSONWriter) Write(filename string, data []models.ProcessedShotData) error {
+	return writeFile(filename, data, w)
+}
+
+func (w JSONWriter) WriteStream(output io.Writer, data []models.ProcessedShotData) error {
+	if len(data) == 0 {
+		return fmt.Errorf("no data to write")
+	}
+
+	clubs := calculators.SummarizeClubs(data)
+	document := jsonDocument{
+		Session: jsonSession{
+			Metadata:    w.Metadata,
+			GeneratedAt: time.Now().UTC(),
+			ShotCount:   len(data),
+			ClubCount:   len(clubs),
+		},
+		Clubs:      clubs,
+		Dispersion: calculators.CalculateDispersions(data),
+		Shots:      data,
+	}
+
+	encoder := json.NewEncoder(output)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(document); err != nil {
+		return fmt.Errorf("writing document: %w", err)
+	}
+	return nil
+}
+
+// NDJSONWriter writes the processed shot data as newline-delimited JSON,
+// one shot per line, for streaming into tools such as jq
+type NDJSONWriter struct{}
+
+func (w NDJSONWriter) Write(filename string, data []models.ProcessedShotData) error {
+	return writeFile(filename, data, w)
+}
+
+func (w NDJSONWriter) WriteStream(output io.Writer, data []models.ProcessedShotData) error {
+	if len(data) == 0 {
+		return fmt.Errorf("no data to write")
+	}
+
+	encoder := json.NewEncoder(output)
+	for _, shot := range data {
+		if err := encoder.Encode(shot); err != nil {
+			return fmt.Errorf("writing record: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package writer
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"albatross/internal/models"
+)
+
+var jsonTestData = []models.ProcessedShotData{
+	{Club: "Dr", Type: "Tee", Target: 255, Total: 250, Side: 5},
+	{Club: "Dr", Type: "Tee", Target: 255, Total: 260, Side: -3},
+	{Club: "7i", Type: "Approach", Target: 150, Total: 150, Side: -2},
+}
+
+func TestJSONWriter(t *testing.T) {
+	testFile := filepath.Join(t.TempDir(), "output.json")
+	writer := JSONWriter{Metadata: Metadata{Session: models.Session{Player: "Palmer Little", Source: "mlm2pro.csv", LaunchMonitorType: "mlm2pro"}}}
+
+	if err := writer.Write(testFile, jsonTestData); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	content, err := os.ReadFile(testFile)
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+
+	var document jsonDocument
+	if err := json.Unmarshal(content, &document); err != nil {
+		t.Fatalf("Output is not valid JSON: %v\n%s", err, content)
+	}
+
+	if document.Session.Player != "Palmer Little" || document.Session.Source != "mlm2pro.csv" || document.Session.LaunchMonitorType != "mlm2pro" {
+		t.Errorf("Unexpected session metadata: %+v", document.Session)
+	}
+	if document.Session.ShotCount != 3 || document.Session.ClubCount != 2 || document.Session.GeneratedAt.IsZero() {
+		t.Errorf("Unexpected session counts: %+v", document.Session)
+	}
+	if len(document.Clubs) != 2 || document.Clubs[0].Club != "Dr" || document.Clubs[0].Shots != 2 || document.Clubs[0].MeanSide != 1 {
+		t.Errorf("Unexpected club summaries: %+v", document.Clubs)
+	}
+	if len(document.Dispersion) != 2 || document.Dispersion[0].Club != "Dr" || document.Dispersion[0].SideVariance != 32 || len(document.Dispersion[0].Ellipses) != 0 {
+		t.Errorf("Unexpected club dispersions: %+v", document.Dispersion)
+	}
+	if !reflect.DeepEqual(document.Shots, jsonTestData) {
+		t.Errorf("Shots = %+v, want %+v", document.Shots, jsonTestData)
+	}
+
+	if err := writer.Write(testFile, []models.ProcessedShotData{}); err == nil {
+		t.Fatalf("Expected error for empty data, got nil")
+	}
+}
+
+func TestNDJSONWriter(t *testing.T) {
+	testFile := filepath.Join(t.TempDir(), "output.ndjson")
+	writer := NDJSONWriter{}
+
+	if err := writer.Write(testFile, jsonTestData); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	file, err := os.Open(testFile)
+	if err != nil {
+		t.Fatalf("Failed to open output file: %v", err)
+	}
+	defer file.Close()
+
+	var shots []models.ProcessedShotData
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var shot models.ProcessedShotData
+		if err := json.Unmarshal(scanner.Bytes(), &shot); err != nil {
+			t.Fatalf("Line %d is not valid JSON: %v", len(shots)+1, err)
+		}
+		shots = append(shots, shot)
+	}
+
+	if !reflect.DeepEqual(shots, jsonTestData) {
+		t.Errorf("Shots = %+v, want %+v", shots, jsonTestData)
+	}
+
+	if err := writer.Write(testFile, nil); err == nil {
+		t.Fatalf("Expected error for empty data, got nil")
+	}
+}
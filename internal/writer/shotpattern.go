@@ -1,17 +1,44 @@
 package writer
 
 import (
-	"fmt"
+	"io"
 
 	"albatross/internal/models"
 	"albatross/utils"
 )
 
-type ShotPatternWriter struct{}
+// ShotPatternWriter writes the Shot Pattern CSV format. Shot Pattern takes a single
+// distance per shot, which is the distance the shot's target was calculated from,
+// or the total distance for shots without a TargetDistance unless UseCarry is set.
+// Shots without a carry distance are written with their total distance, as they were
+// left out of their club's carry target, and a Distance column records which distance
+// each shot was written with whenever any shot is written with its carry.
+type ShotPatternWriter struct {
+	UseCarry bool // Write the carry distance in place of the total distance of shots without a TargetDistance
+}
 
 func (w ShotPatternWriter) Write(filename string, data []models.ProcessedShotData) error {
-	if len(data) == 0 {
-		return fmt.Errorf("no data to write")
+	return writeFile(filename, data, w)
+}
+
+func (w ShotPatternWriter) WriteStream(output io.Writer, data []models.ProcessedShotData) error {
+	written := make([]models.ProcessedShotData, len(data))
+	for i, shot := range data {
+		carried := w.useCarry(shot) && shot.Carry > 0
+		shot.TargetDistance = "total"
+		if carried {
+			shot.Total = shot.Carry
+			shot.TargetDistance = "carry"
+		}
+		written[i] = shot
+	}
+	return utils.WriteCSVTo(output, written)
+}
+
+// useCarry reports whether a shot's carry distance is written in place of its total distance
+func (w ShotPatternWriter) useCarry(shot models.ProcessedShotData) bool {
+	if shot.TargetDistance != "" {
+		return shot.TargetDistance == "carry"
 	}
-	return utils.WriteCSV(filename, data)
+	return w.UseCarry
 }
@@ -1,7 +1,15 @@
 // Package writer provides interfaces and implementations for writing processed shot data to various output formats.
 package writer
 
-import "albatross/internal/models"
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"albatross/internal/models"
+	"albatross/utils"
+)
 
 // Writer interface defines the method that any writer should implement.
 // This interface allows for different output formats to be used interchangeably,
@@ -12,3 +20,111 @@ type Writer interface {
 	// It returns an error if the writing process encounters any issues.
 	Write(filename string, data []models.ProcessedShotData) error
 }
+
+// StreamWriter is a Writer that can also write to any io.Writer, such as stdout,
+// an in-memory buffer or an HTTP response. Every built-in output format is a StreamWriter.
+type StreamWriter interface {
+	Writer
+
+	// WriteStream writes the data to output in the same format Write writes to a file.
+	WriteStream(output io.Writer, data []models.ProcessedShotData) error
+}
+
+// Stdout is the output filename that writes to standard output instead of a file
+const Stdout = "-"
+
+// Metadata describes the session being written, for output formats that record it
+type Metadata struct {
+	models.Session
+	Distance             string            `json:"distance,omitempty"`             // The distance targets were calculated from, "total" or "carry"
+	TypeDistances        map[string]string `json:"typeDistances,omitempty"`        // Shot types whose targets were calculated from another distance, keyed by type
+	ClubDistances        map[string]string `json:"clubDistances,omitempty"`        // Clubs whose targets were calculated from another distance, keyed by club type
+	TargetStrategy       string            `json:"targetStrategy,omitempty"`       // How targets were calculated from the distances, e.g. "median" or "p75"
+	ClubTargetStrategies map[string]string `json:"clubTargetStrategies,omitempty"` // Clubs whose targets were calculated another way, keyed by club type
+	OutlierDetector      string            `json:"outlierDetector,omitempty"`      // How outliers were detected, e.g. "iqr:1.5"; empty when they weren't
+	ExcludeOutliers      bool              `json:"excludeOutliers,omitempty"`      // Whether outliers were left out of targets
+}
+
+// Distances lists the distances targets can be calculated from
+var Distances = []string{"total", "carry"}
+
+// Formats lists the supported output format names
+var Formats = []string{"shotpattern", "json", "ndjson", "html"}
+
+// New returns the Writer for an output format along with the suffix to give its output file.
+// Format names are case-insensitive.
+func New(format string, metadata Metadata) (StreamWriter, string, error) {
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case "shotpattern":
+		return ShotPatternWriter{UseCarry: metadata.Distance == "carry"}, "_processed.csv", nil
+	case "json":
+		return JSONWriter{Metadata: metadata}, "_processed.json", nil
+	case "ndjson":
+		return NDJSONWriter{}, "_processed.ndjson", nil
+	case "html":
+		return HTMLWriter{Metadata: metadata}, "_report.html", nil
+	default:
+		return nil, "", fmt.Errorf("unsupported output format: %s", format)
+	}
+}
+
+// Output pairs an output format's Writer with the file it writes to
+type Output struct {
+	Format   string // The normalized format name
+	Filename string // The file the Writer writes to
+	Writer   StreamWriter
+}
+
+// Write writes the data to the output's file, or to stdout when its filename is Stdout
+func (o Output) Write(data []models.ProcessedShotData) error {
+	if o.Filename == Stdout {
+		return o.Writer.WriteStream(os.Stdout, data)
+	}
+	return o.Writer.Write(o.Filename, data)
+}
+
+// NewOutputs returns an Output for each format in a comma-separated list, with the
+// output filename derived from the input file. Repeated formats are written once.
+// It returns an error naming the first unsupported format.
+func NewOutputs(formats, inputFile string, metadata Metadata) ([]Output, error) {
+	var outputs []Output
+	seen := make(map[string]bool)
+	for _, format := range strings.Split(formats, ",") {
+		name := strings.ToLower(strings.TrimSpace(format))
+		if seen[name] {
+			continue
+		}
+		writer, suffix, err := New(name, metadata)
+		if err != nil {
+			return nil, err
+		}
+		seen[name] = true
+		outputs = append(outputs, Output{
+			Format:   name,
+			Filename: utils.ReplaceFileExtension(inputFile, suffix),
+			Writer:   writer,
+		})
+	}
+	return outputs, nil
+}
+
+// writeFile creates a file and streams the data into it with the given StreamWriter.
+// No file is created when there is no data to write.
+func writeFile(filename string, data []models.ProcessedShotData, stream StreamWriter) error {
+	if len(data) == 0 {
+		return fmt.Errorf("no data to write")
+	}
+
+	file, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("creating file: %w", err)
+	}
+	if err := stream.WriteStream(file, data); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("closing file: %w", err)
+	}
+	return nil
+}
@@ -1,7 +1,11 @@
 package writer
 
 import (
+	"bytes"
 	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 
 	"albatross/internal/models"
@@ -51,3 +55,197 @@ Dr,Tee,250.00,260.00,5.00
 		t.Fatalf("Expected error for empty data, got nil")
 	}
 }
+
+func TestShotPatternWriterUseCarry(t *testing.T) {
+	writer := ShotPatternWriter{UseCarry: true}
+	testData := []models.ProcessedShotData{
+		{Club: "7i", Type: "Approach", Target: 143, Carry: 141.5, Total: 150, Side: -2},
+	}
+
+	var buffer bytes.Buffer
+	if err := writer.WriteStream(&buffer, testData); err != nil {
+		t.Fatalf("WriteStream failed: %v", err)
+	}
+	expectedContent := "Club,Type,Target,Total,Side,Distance\n7i,Approach,143.00,141.50,-2.00,carry\n"
+	if buffer.String() != expectedContent {
+		t.Errorf("Content mismatch.\nExpected:\n%s\nGot:\n%s", expectedContent, buffer.String())
+	}
+	if testData[0].Total != 150 || testData[0].TargetDistance != "" {
+		t.Errorf("WriteStream modified the input data: %+v", testData[0])
+	}
+
+	// Shots without a carry distance are written with their total instead
+	buffer.Reset()
+	if err := writer.WriteStream(&buffer, []models.ProcessedShotData{{Club: "Dr", Type: "Tee", Total: 250}}); err != nil {
+		t.Fatalf("WriteStream failed: %v", err)
+	}
+	expectedContent = "Club,Type,Target,Total,Side\nDr,Tee,0.00,250.00,0.00\n"
+	if buffer.String() != expectedContent {
+		t.Errorf("Content mismatch.\nExpected:\n%s\nGot:\n%s", expectedContent, buffer.String())
+	}
+}
+
+func TestShotPatternWriterTargetDistance(t *testing.T) {
+	// Each shot is written with the distance its target was calculated from, whatever UseCarry says
+	testData := []models.ProcessedShotData{
+		{Club: "Dr", Type: "Tee", Target: 250, Carry: 232, Total: 255, Side: 4, TargetDistance: "total"},
+		{Club: "7i", Type: "Approach", Target: 143, Carry: 141.5, Total: 150, Side: -2, TargetDistance: "carry"},
+		// Left out of the carry target for want of a carry distance, so written with its total
+		{Club: "7i", Type: "Approach", Target: 143, Total: 155, Side: 3, TargetDistance: "carry"},
+	}
+
+	for _, writer := range []ShotPatternWriter{{}, {UseCarry: true}} {
+		var buffer bytes.Buffer
+		if err := writer.WriteStream(&buffer, testData); err != nil {
+			t.Fatalf("WriteStream failed: %v", err)
+		}
+		expectedContent := "Club,Type,Target,Total,Side,Distance\nDr,Tee,250.00,255.00,4.00,total\n7i,Approach,143.00,141.50,-2.00,carry\n7i,Approach,143.00,155.00,3.00,total\n"
+		if buffer.String() != expectedContent {
+			t.Errorf("Content mismatch with UseCarry %v.\nExpected:\n%s\nGot:\n%s", writer.UseCarry, expectedContent, buffer.String())
+		}
+	}
+}
+
+func TestWriteStream(t *testing.T) {
+	testData := []models.ProcessedShotData{
+		{Club: "Dr", Type: "Tee", Target: 250, Total: 260, Side: 5},
+	}
+	tests := []struct {
+		name     string
+		writer   StreamWriter
+		expected string
+	}{
+		{"ShotPattern", ShotPatternWriter{}, "Club,Type,Target,Total,Side\nDr,Tee,250.00,260.00,5.00\n"},
+		{"NDJSON", NDJSONWriter{}, `{"club":"Dr","type":"Tee","target":250,"total":260,"side":5}` + "\n"},
+		{"JSON", JSONWriter{}, `"shotCount": 1`},
+		{"HTML", HTMLWriter{}, "<!DOCTYPE html>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buffer bytes.Buffer
+			if err := tt.writer.WriteStream(&buffer, testData); err != nil {
+				t.Fatalf("WriteStream failed: %v", err)
+			}
+			if !strings.Contains(buffer.String(), tt.expected) {
+				t.Errorf("WriteStream output = %q, want it to contain %q", buffer.String(), tt.expected)
+			}
+
+			// Writing to a file produces the same output as writing to a stream
+			if tt.name == "ShotPattern" || tt.name == "NDJSON" {
+				testFile := filepath.Join(t.TempDir(), "output")
+				if err := tt.writer.Write(testFile, testData); err != nil {
+					t.Fatalf("Write failed: %v", err)
+				}
+				content, err := os.ReadFile(testFile)
+				if err != nil {
+					t.Fatalf("Failed to read output file: %v", err)
+				}
+				if string(content) != buffer.String() {
+					t.Errorf("File content %q differs from stream output %q", content, buffer.String())
+				}
+			}
+
+			buffer.Reset()
+			if err := tt.writer.WriteStream(&buffer, nil); err == nil {
+				t.Errorf("Expected error for empty data, got nil")
+			}
+		})
+	}
+}
+
+func TestWriteFileEmptyData(t *testing.T) {
+	testFile := filepath.Join(t.TempDir(), "output.csv")
+	if err := (ShotPatternWriter{}).Write(testFile, nil); err == nil {
+		t.Fatalf("Expected error for empty data, got nil")
+	}
+	if _, err := os.Stat(testFile); !os.IsNotExist(err) {
+		t.Errorf("Expected no file to be created for empty data, got %v", err)
+	}
+}
+
+func TestOutputWrite(t *testing.T) {
+	testFile := filepath.Join(t.TempDir(), "output.ndjson")
+	output := Output{Format: "ndjson", Filename: testFile, Writer: NDJSONWriter{}}
+	if err := output.Write([]models.ProcessedShotData{{Club: "7i", Type: "Approach", Total: 150}}); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if _, err := os.Stat(testFile); err != nil {
+		t.Errorf("Expected the output file to be written: %v", err)
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		format   string
+		expected Writer
+		suffix   string
+		wantErr  bool
+	}{
+		{"shotpattern", ShotPatternWriter{}, "_processed.csv", false},
+		{"JSON", JSONWriter{Metadata: Metadata{Session: models.Session{Source: "in.csv"}}}, "_processed.json", false},
+		{"ndjson", NDJSONWriter{}, "_processed.ndjson", false},
+		{"html", HTMLWriter{Metadata: Metadata{Session: models.Session{Source: "in.csv"}}}, "_report.html", false},
+		{"xml", nil, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			writer, suffix, err := New(tt.format, Metadata{Session: models.Session{Source: "in.csv"}})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("New(%q) error = %v, wantErr %v", tt.format, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(writer, tt.expected) || suffix != tt.suffix {
+				t.Errorf("New(%q) = %#v, %q, want %#v, %q", tt.format, writer, suffix, tt.expected, tt.suffix)
+			}
+		})
+	}
+}
+
+func TestNewOutputs(t *testing.T) {
+	metadata := Metadata{Session: models.Session{Source: "data/in.csv"}}
+	tests := []struct {
+		name     string
+		formats  string
+		expected []Output
+		wantErr  bool
+	}{
+		{
+			name:    "Single format",
+			formats: "shotpattern",
+			expected: []Output{
+				{Format: "shotpattern", Filename: "data/in_processed.csv", Writer: ShotPatternWriter{}},
+			},
+		},
+		{
+			name:    "Format list",
+			formats: "shotpattern, JSON,html",
+			expected: []Output{
+				{Format: "shotpattern", Filename: "data/in_processed.csv", Writer: ShotPatternWriter{}},
+				{Format: "json", Filename: "data/in_processed.json", Writer: JSONWriter{Metadata: metadata}},
+				{Format: "html", Filename: "data/in_report.html", Writer: HTMLWriter{Metadata: metadata}},
+			},
+		},
+		{
+			name:    "Repeated format",
+			formats: "ndjson,NDJSON",
+			expected: []Output{
+				{Format: "ndjson", Filename: "data/in_processed.ndjson", Writer: NDJSONWriter{}},
+			},
+		},
+		{name: "Unsupported format", formats: "json,xml", wantErr: true},
+		{name: "Empty entry", formats: "json,", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outputs, err := NewOutputs(tt.formats, "data/in.csv", metadata)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewOutputs(%q) error = %v, wantErr %v", tt.formats, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(outputs, tt.expected) {
+				t.Errorf("NewOutputs(%q) = %#v, want %#v", tt.formats, outputs, tt.expected)
+			}
+		})
+	}
+}
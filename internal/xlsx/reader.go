@@ -0,0 +1,326 @@
+// Package xlsx provides a minimal reader for the worksheets of Excel XLSX workbooks.
+// It reads cell values as strings, row by row, so workbooks can be processed like CSV files.
+package xlsx
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+type workbookXML struct {
+	Sheets []struct {
+		Name string `xml:"name,attr"`
+		ID   string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type relationshipsXML struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+type sharedStringsXML struct {
+	Items []richTextXML `xml:"si"`
+}
+
+type richTextXML struct {
+	Text string `xml:"t"`
+	Runs []struct {
+		Text string `xml:"t"`
+	} `xml:"r"`
+}
+
+// String joins the plain text and any formatted runs of a rich text element
+func (richText richTextXML) String() string {
+	var builder strings.Builder
+	builder.WriteString(richText.Text)
+	for _, run := range richText.Runs {
+		builder.WriteString(run.Text)
+	}
+	return builder.String()
+}
+
+type worksheetXML struct {
+	Rows []struct {
+		Index int `xml:"r,attr"`
+		Cells []struct {
+			Ref    string      `xml:"r,attr"`
+			Type   string      `xml:"t,attr"`
+			Value  string      `xml:"v"`
+			Inline richTextXML `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// Sheet is a worksheet in a workbook
+type Sheet struct {
+	Name string
+	part string
+}
+
+// Workbook is an open XLSX file
+type Workbook struct {
+	archive       *zip.ReadCloser
+	sheets        []Sheet
+	sharedStrings []string
+}
+
+// Open opens an XLSX workbook and reads its sheet list and shared strings
+func Open(filename string) (*Workbook, error) {
+	archive, err := zip.OpenReader(filename)
+	if err != nil {
+		return nil, fmt.Errorf("opening workbook '%s': %w", filename, err)
+	}
+
+	workbook := &Workbook{archive: archive}
+	if err := workbook.readSheets(); err != nil {
+		archive.Close()
+		return nil, err
+	}
+	if err := workbook.readSharedStrings(); err != nil {
+		archive.Close()
+		return nil, err
+	}
+	return workbook, nil
+}
+
+// Close closes the underlying XLSX file
+func (workbook *Workbook) Close() error {
+	return workbook.archive.Close()
+}
+
+// Sheets returns the worksheets of the workbook in workbook order
+func (workbook *Workbook) Sheets() []Sheet {
+	return workbook.sheets
+}
+
+// Select returns the sheets matching a selector: a sheet name (ignoring case), a 1-based
+// sheet index, or every sheet when the selector is empty
+func (workbook *Workbook) Select(selector string) ([]Sheet, error) {
+	selector = strings.TrimSpace(selector)
+	if selector == "" {
+		return workbook.sheets, nil
+	}
+
+	for _, sheet := range workbook.sheets {
+		if strings.EqualFold(sheet.Name, selector) {
+			return []Sheet{sheet}, nil
+		}
+	}
+
+	if index, err := strconv.Atoi(selector); err == nil {
+		if index < 1 || index > len(workbook.sheets) {
+			return nil, fmt.Errorf("sheet index %d out of range, workbook has %d sheets", index, len(workbook.sheets))
+		}
+		return []Sheet{workbook.sheets[index-1]}, nil
+	}
+
+	return nil, fmt.Errorf("sheet '%s' not found", selector)
+}
+
+// Rows reads every row of a sheet. Cells missing from a row are returned as empty
+// strings, and rows skipped by the workbook are returned as a single empty cell so
+// that row numbers are preserved.
+func (workbook *Workbook) Rows(sheet Sheet) ([][]string, error) {
+	var worksheet worksheetXML
+	if err := workbook.decodePart(sheet.part, &worksheet); err != nil {
+		return nil, fmt.Errorf("reading sheet '%s': %w", sheet.Name, err)
+	}
+
+	var rows [][]string
+	for _, xmlRow := range worksheet.Rows {
+		// Fill in rows the workbook leaves out
+		for xmlRow.Index > len(rows)+1 {
+			rows = append(rows, []string{""})
+		}
+
+		var row []string
+		for position, cell := range xmlRow.Cells {
+			column := position
+			if cell.Ref != "" {
+				column = columnIndex(cell.Ref)
+			}
+			for len(row) < column {
+				row = append(row, "")
+			}
+
+			value, err := workbook.cellValue(cell.Type, cell.Value, cell.Inline)
+			if err != nil {
+				return nil, fmt.Errorf("reading cell %s of sheet '%s': %w", cell.Ref, sheet.Name, err)
+			}
+			row = append(row, value)
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// cellValue converts a cell's stored value into the string shown in Excel
+func (workbook *Workbook) cellValue(cellType, value string, inline richTextXML) (string, error) {
+	switch cellType {
+	case "s":
+		index, err := strconv.Atoi(value)
+		if err != nil || index < 0 || index >= len(workbook.sharedStrings) {
+			return "", fmt.Errorf("invalid shared string index %q", value)
+		}
+		return workbook.sharedStrings[index], nil
+	case "inlineStr":
+		return inline.String(), nil
+	case "b":
+		if value == "1" {
+			return "TRUE", nil
+		}
+		return "FALSE", nil
+	case "str", "e":
+		return value, nil
+	default:
+		// Numbers are stored with full binary precision (e.g. 80.700000000000003),
+		// so they are reformatted to their shortest representation
+		if number, err := strconv.ParseFloat(value, 64); err == nil {
+			return strconv.FormatFloat(number, 'f', -1, 64), nil
+		}
+		return value, nil
+	}
+}
+
+// readSheets reads the sheet names from the workbook and resolves the part that holds each one
+func (workbook *Workbook) readSheets() error {
+	var book workbookXML
+	if err := workbook.decodePart("xl/workbook.xml", &book); err != nil {
+		return fmt.Errorf("reading workbook: %w", err)
+	}
+
+	var relationships relationshipsXML
+	if err := workbook.decodePart("xl/_rels/workbook.xml.rels", &relationships); err != nil {
+		return fmt.Errorf("reading workbook relationships: %w", err)
+	}
+
+	targets := make(map[string]string, len(relationships.Relationships))
+	for _, relationship := range relationships.Relationships {
+		target := relationship.Target
+		if strings.HasPrefix(target, "/") {
+			target = strings.TrimPrefix(target, "/")
+		} else {
+			target = path.Join("xl", target)
+		}
+		targets[relationship.ID] = target
+	}
+
+	for _, sheet := range book.Sheets {
+		part, ok := targets[sheet.ID]
+		if !ok {
+			return fmt.Errorf("sheet '%s' has no worksheet part", sheet.Name)
+		}
+		workbook.sheets = append(workbook.sheets, Sheet{Name: sheet.Name, part: part})
+	}
+	return nil
+}
+
+// readSharedStrings reads the workbook's shared string table, which is optional
+func (workbook *Workbook) readSharedStrings() error {
+	if workbook.findPart("xl/sharedStrings.xml") == nil {
+		return nil
+	}
+
+	var sharedStrings sharedStringsXML
+	if err := workbook.decodePart("xl/sharedStrings.xml", &sharedStrings); err != nil {
+		return fmt.Errorf("reading shared strings: %w", err)
+	}
+
+	workbook.sharedStrings = make([]string, len(sharedStrings.Items))
+	for i, item := range sharedStrings.Items {
+		workbook.sharedStrings[i] = item.String()
+	}
+	return nil
+}
+
+// decodePart decodes an XML part of the workbook archive into v
+func (workbook *Workbook) decodePart(name string, v interface{}) error {
+	file := workbook.findPart(name)
+	if file == nil {
+		return fmt.Errorf("missing part '%s'", name)
+	}
+
+	reader, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	return xml.NewDecoder(reader).Decode(v)
+}
+
+// findPart returns the archive entry with the given name, ignoring case as Excel does
+func (workbook *Workbook) findPart(name string) *zip.File {
+	for _, file := range workbook.archive.File {
+		if strings.EqualFold(file.Name, name) {
+			return file
+		}
+	}
+	return nil
+}
+
+// columnIndex converts the letters of a cell reference such as "AB12" into a 0-based column index
+func columnIndex(ref string) int {
+	index := 0
+	for _, r := range strings.ToUpper(ref) {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		index = index*26 + int(r-'A'+1)
+	}
+	return index - 1
+}
+
+// Reader reads the rows of one or more sheets in sequence. Like csv.Reader,
+// Read returns io.EOF once every row has been read.
+type Reader struct {
+	rows [][]string
+	next int
+}
+
+// NewReader reads the sheets of a workbook matching the selector (see Workbook.Select)
+// and returns a Reader over their rows. Sheets are separated by an empty row so that
+// a data block never continues from one sheet into the next.
+func NewReader(filename string, sheetSelector string) (*Reader, error) {
+	workbook, err := Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer workbook.Close()
+
+	sheets, err := workbook.Select(sheetSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := &Reader{}
+	for i, sheet := range sheets {
+		rows, err := workbook.Rows(sheet)
+		if err != nil {
+			return nil, err
+		}
+		if i > 0 {
+			reader.rows = append(reader.rows, []string{""})
+		}
+		reader.rows = append(reader.rows, rows...)
+	}
+	return reader, nil
+}
+
+// Read returns the next row, or io.EOF when there are no rows left
+func (reader *Reader) Read() ([]string, error) {
+	if reader.next >= len(reader.rows) {
+		return nil, io.EOF
+	}
+	row := reader.rows[reader.next]
+	reader.next++
+	return row, nil
+}
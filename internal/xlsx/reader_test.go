@@ -0,0 +1,192 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// writeTestWorkbook builds a two sheet workbook exercising shared, rich and inline strings,
+// numbers stored with binary precision, skipped cells and skipped rows
+func writeTestWorkbook(t *testing.T) string {
+	t.Helper()
+
+	parts := map[string]string{
+		"xl/workbook.xml": `<?xml version="1.0" encoding="UTF-8"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+  <sheets>
+    <sheet name="Driver" sheetId="1" r:id="rId1"/>
+    <sheet name="Wedges" sheetId="2" r:id="rId2"/>
+  </sheets>
+</workbook>`,
+		"xl/_rels/workbook.xml.rels": `<?xml version="1.0" encoding="UTF-8"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+  <Relationship Id="rId1" Target="worksheets/sheet1.xml"/>
+  <Relationship Id="rId2" Target="/xl/worksheets/sheet2.xml"/>
+</Relationships>`,
+		"xl/sharedStrings.xml": `<?xml version="1.0" encoding="UTF-8"?>
+<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+  <si><t>Club Type</t></si>
+  <si><t>Total Distance</t></si>
+  <si><r><t>Side </t></r><r><t>Carry</t></r></si>
+  <si><t>Driver</t></si>
+</sst>`,
+		"xl/worksheets/sheet1.xml": `<?xml version="1.0" encoding="UTF-8"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+  <sheetData>
+    <row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c><c r="C1" t="s"><v>2</v></c></row>
+    <row r="2"><c r="A2" t="s"><v>3</v></c><c r="B2"><v>250.69999999999999</v></c><c r="C2"><v>-4</v></c></row>
+    <row r="4"><c r="A4" t="inlineStr"><is><t>Average</t></is></c><c r="C4"><v>1.5</v></c></row>
+  </sheetData>
+</worksheet>`,
+		"xl/worksheets/sheet2.xml": `<?xml version="1.0" encoding="UTF-8"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+  <sheetData>
+    <row r="1"><c r="A1" t="str"><v>pw</v></c><c r="B1"><v>93.5</v></c><c r="C1" t="b"><v>1</v></c></row>
+  </sheetData>
+</worksheet>`,
+	}
+
+	filename := filepath.Join(t.TempDir(), "session.xlsx")
+	file, err := os.Create(filename)
+	if err != nil {
+		t.Fatalf("Failed to create workbook: %v", err)
+	}
+	defer file.Close()
+
+	archive := zip.NewWriter(file)
+	for name, content := range parts {
+		part, err := archive.Create(name)
+		if err != nil {
+			t.Fatalf("Failed to create part %s: %v", name, err)
+		}
+		if _, err := io.WriteString(part, content); err != nil {
+			t.Fatalf("Failed to write part %s: %v", name, err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		t.Fatalf("Failed to close workbook: %v", err)
+	}
+	return filename
+}
+
+func TestWorkbookSheetsAndRows(t *testing.T) {
+	workbook, err := Open(writeTestWorkbook(t))
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer workbook.Close()
+
+	sheets := workbook.Sheets()
+	if len(sheets) != 2 || sheets[0].Name != "Driver" || sheets[1].Name != "Wedges" {
+		t.Fatalf("Unexpected sheets: %+v", sheets)
+	}
+
+	rows, err := workbook.Rows(sheets[0])
+	if err != nil {
+		t.Fatalf("Rows failed: %v", err)
+	}
+
+	expected := [][]string{
+		{"Club Type", "Total Distance", "Side Carry"},
+		{"Driver", "250.7", "-4"},
+		{""},
+		{"Average", "", "1.5"},
+	}
+	if !reflect.DeepEqual(rows, expected) {
+		t.Errorf("Rows() = %q, want %q", rows, expected)
+	}
+}
+
+func TestWorkbookSelect(t *testing.T) {
+	workbook, err := Open(writeTestWorkbook(t))
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer workbook.Close()
+
+	tests := []struct {
+		name     string
+		selector string
+		expected []string
+		wantErr  bool
+	}{
+		{"All sheets", "", []string{"Driver", "Wedges"}, false},
+		{"By name", "wedges", []string{"Wedges"}, false},
+		{"By index", "1", []string{"Driver"}, false},
+		{"Index out of range", "3", nil, true},
+		{"Unknown name", "Putter", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sheets, err := workbook.Select(tt.selector)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Select(%q) error = %v, wantErr %v", tt.selector, err, tt.wantErr)
+			}
+			var names []string
+			for _, sheet := range sheets {
+				names = append(names, sheet.Name)
+			}
+			if !reflect.DeepEqual(names, tt.expected) {
+				t.Errorf("Select(%q) = %v, want %v", tt.selector, names, tt.expected)
+			}
+		})
+	}
+}
+
+func TestReader(t *testing.T) {
+	reader, err := NewReader(writeTestWorkbook(t), "")
+	if err != nil {
+		t.Fatalf("NewReader failed: %v", err)
+	}
+
+	var rows [][]string
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Read failed: %v", err)
+		}
+		rows = append(rows, row)
+	}
+
+	// Four rows from the first sheet, a separator, then the second sheet
+	if len(rows) != 6 {
+		t.Fatalf("Expected 6 rows, got %d: %q", len(rows), rows)
+	}
+	if !reflect.DeepEqual(rows[4], []string{""}) {
+		t.Errorf("Expected an empty separator row between sheets, got %q", rows[4])
+	}
+	if !reflect.DeepEqual(rows[5], []string{"pw", "93.5", "TRUE"}) {
+		t.Errorf("Unexpected row from second sheet: %q", rows[5])
+	}
+
+	if _, err := NewReader(filepath.Join(t.TempDir(), "missing.xlsx"), ""); err == nil {
+		t.Errorf("Expected error for missing workbook, got nil")
+	}
+}
+
+func TestColumnIndex(t *testing.T) {
+	tests := []struct {
+		ref      string
+		expected int
+	}{
+		{"A1", 0},
+		{"C12", 2},
+		{"Z3", 25},
+		{"AA1", 26},
+		{"ab7", 27},
+	}
+
+	for _, tt := range tests {
+		if result := columnIndex(tt.ref); result != tt.expected {
+			t.Errorf("columnIndex(%q) = %d, want %d", tt.ref, result, tt.expected)
+		}
+	}
+}
@@ -3,15 +3,22 @@
 package main
 
 import (
+	"errors"
 	"flag"
+	"fmt"
+	"io"
 	"os"
 	"strings"
+	"text/tabwriter"
 
 	"albatross/internal/calculators"
+	"albatross/internal/config"
 	"albatross/internal/logging"
+	"albatross/internal/models"
 	"albatross/internal/parsers"
+	"albatross/internal/processors"
+	"albatross/internal/reader"
 	"albatross/internal/writer"
-	"albatross/utils"
 )
 
 // main is the entry point of the application. It handles command-line arguments,
@@ -20,27 +27,94 @@ func main() {
 	// Initialize the logger
 	logging.InitLogger()
 
+	// List the registered launch monitors when asked instead of processing a file
+	if len(os.Args) > 1 && os.Args[1] == "monitors" {
+		if err := listLaunchMonitors(os.Stdout); err != nil {
+			logging.Error("Error listing launch monitors", err, nil)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Report the gaps between the clubs in a session instead of processing it
+	if len(os.Args) > 1 && os.Args[1] == "gapping" {
+		if err := runGapping(os.Args[2:], os.Stdout); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return
+			}
+			logging.Error("Error analysing gapping", err, nil)
+			os.Exit(1)
+		}
+		return
+	}
+
 	// Define command-line flags
-	launchMonitorType := flag.String("type", "", "Launch monitor type (e.g., mlm2pro)")
-	inputFile := flag.String("input", "", "Input CSV file path")
+	supportedTypes := strings.Join(reader.Names(), ", ")
+	launchMonitorType := flag.String("type", "", fmt.Sprintf("Launch monitor type (one of %s); detected from the file when omitted", supportedTypes))
+	inputFile := flag.String("input", "", "Input file path")
+	profileFile := flag.String("profile", "", "JSON or YAML profile describing the columns of an unsupported launch monitor's CSV export")
+	sheet := flag.String("sheet", "", "XLSX sheet to read, by name or 1-based index; every sheet when omitted")
+	format := flag.String("format", "shotpattern", fmt.Sprintf("Comma-separated output formats (any of %s)", strings.Join(writer.Formats, ", ")))
+	distance := flag.String("distance", "", fmt.Sprintf("Distance to calculate targets from and write to ShotPattern output (one of %s), overriding the configuration's per-type and per-club distances; total when omitted", strings.Join(writer.Distances, ", ")))
+	configFile := flag.String("config", "", "JSON configuration file with the target strategy, distances and per-type or per-club overrides")
+	targetStrategy := flag.String("target-strategy", "", fmt.Sprintf("How a club's target is calculated from its distances (one of %s); median when omitted", strings.Join(calculators.TargetStrategies, ", ")))
+	includeFlagged := flag.Bool("include-flagged", false, "Include shots flagged as likely launch monitor misreads when calculating targets")
+	outliers := flag.String("outliers", "", fmt.Sprintf("Mark each club's statistical outliers (one of %s); no outliers are detected when omitted", strings.Join(calculators.OutlierDetectors, ", ")))
+	excludeOutliers := flag.Bool("exclude-outliers", false, "Leave outliers out when calculating targets, detecting them with iqr unless -outliers is given")
+	units := flag.String("units", models.UnitsImperial, fmt.Sprintf("Unit system to write distances and speeds in (one of %s)", strings.Join(models.UnitSystems, ", ")))
+	inputUnits := flag.String("input-units", "", fmt.Sprintf("Unit system of exports that don't give their units (one of %s); imperial when omitted", strings.Join(models.UnitSystems, ", ")))
+	outputFile := flag.String("output", "", "Output file path, or - for stdout; derived from the input file when omitted")
+	strict := flag.Bool("strict", false, "Fail on the first row that cannot be read instead of skipping it")
+	diagnosticsFile := flag.String("diagnostics", "", "CSV file to write the rows that could not be read to")
 	flag.Parse()
 
+	options := parsers.Options{Sheet: *sheet, Strict: *strict, InputUnits: *inputUnits}
+
 	// Validate command-line arguments
-	if *launchMonitorType == "" || *inputFile == "" {
-		logging.Fatal("Usage: go run main.go -type <launch_monitor_type> -input <input_csv_file>", nil)
+	if *inputFile == "" {
+		logging.Fatal("Usage: go run main.go [-type <launch_monitor_type>] -input <input_file> | go run main.go monitors | go run main.go gapping -input <input_file>", nil)
 	}
 
-	normalizedType := normalizeLaunchMonitorType(*launchMonitorType)
-
-	// Validate launch monitor type
-	if !isValidLaunchMonitorType(normalizedType) {
-		logging.Fatal("Error: Invalid launch monitor type. Supported type is mlm2pro.", logging.Fields{
-			"providedType": normalizedType,
+	// Register any profile and detect the launch monitor type when it isn't given
+	normalizedType, err := resolveLaunchMonitorType(*launchMonitorType, *profileFile, *inputFile, options)
+	if err != nil {
+		logging.Fatal("Error: Unable to determine the launch monitor type.", logging.Fields{
+			"inputFile": *inputFile,
+			"error":     err.Error(),
 		})
 	}
 
 	// Process shot data from the input file
-	shotData, err := parsers.ProcessShotData(*inputFile, normalizedType)
+	result, err := parsers.ReadFile(*inputFile, normalizedType, options)
+
+	// Report the rows that were skipped because they could not be read, which
+	// explain why a file without a single readable row failed
+	if len(result.Diagnostics) > 0 {
+		skippedByColumn := logging.Fields{}
+		for _, count := range parsers.CountDiagnostics(result.Diagnostics) {
+			column := count.Column
+			if column == "" {
+				column = "(row)"
+			}
+			skippedByColumn[column] = count.Count
+		}
+		logging.Info("Skipped rows that could not be read", logging.Fields{
+			"rowsSkipped": len(result.Diagnostics),
+			"byColumn":    skippedByColumn,
+		})
+	}
+	if *diagnosticsFile != "" && (err == nil || len(result.Diagnostics) > 0) {
+		if err := writeDiagnostics(*diagnosticsFile, result.Diagnostics); err != nil {
+			logging.Error("Error writing diagnostics file", err, logging.Fields{
+				"diagnosticsFile": *diagnosticsFile,
+			})
+			os.Exit(1)
+		}
+		logging.Info("Wrote diagnostics file", logging.Fields{
+			"diagnosticsFile": *diagnosticsFile,
+			"rowsSkipped":     len(result.Diagnostics),
+		})
+	}
 	if err != nil {
 		logging.Error("Error processing shot data", err, logging.Fields{
 			"inputFile":         *inputFile,
@@ -48,42 +122,236 @@ func main() {
 		})
 		os.Exit(1)
 	}
+	session, shotData := result.Session, result.Shots
 
 	logging.Info("Processed shot data", logging.Fields{
-		"count": len(shotData),
+		"count":   len(shotData),
+		"device":  session.Device,
+		"player":  session.Player,
+		"started": session.StartTime,
 	})
 
-	// Calculate targets based on the processed shot data
-	calculators.CalculateTargets(&shotData)
+	// Flag physically implausible shots, which are likely launch monitor misreads
+	if flagged := processors.ValidateShots(shotData); flagged > 0 {
+		logging.Info("Flagged likely misreads", logging.Fields{
+			"shotsFlagged":   flagged,
+			"includeFlagged": *includeFlagged,
+		})
+	}
+
+	// Calculate targets based on the processed shot data, with the strategy from -target-strategy
+	// and the distance from -distance taking precedence over the configuration file's
+	var settings config.Config
+	if *configFile != "" {
+		settings, err = config.Load(*configFile)
+		if err != nil {
+			logging.Fatal("Error: Unable to load configuration.", logging.Fields{
+				"configFile": *configFile,
+				"error":      err.Error(),
+			})
+		}
+	}
+	if *targetStrategy == "" {
+		*targetStrategy = settings.TargetStrategy
+	}
+	strategy, err := calculators.ParseTargetStrategy(*targetStrategy)
+	if err != nil {
+		logging.Fatal(fmt.Sprintf("Error: Invalid target strategy. Supported strategies are %s.", strings.Join(calculators.TargetStrategies, ", ")), logging.Fields{
+			"providedTargetStrategy": *targetStrategy,
+			"error":                  err.Error(),
+		})
+	}
+	clubStrategies, err := settings.ClubTargetStrategies()
+	if err != nil {
+		logging.Fatal("Error: Invalid club target strategy in configuration.", logging.Fields{
+			"configFile": *configFile,
+			"error":      err.Error(),
+		})
+	}
+
+	targetOptions := calculators.TargetOptions{
+		Distance:        *distance,
+		IncludeFlagged:  *includeFlagged,
+		ExcludeOutliers: *excludeOutliers,
+		Strategy:        strategy,
+		ClubStrategies:  clubStrategies,
+	}
+	if *distance == "" {
+		targetOptions.Distance = settings.Distance
+		targetOptions.TypeDistances = settings.TypeDistances()
+		targetOptions.ClubDistances = settings.ClubDistances()
+	}
+	if targetOptions.Distance == "" {
+		targetOptions.Distance = calculators.DistanceTotal
+	}
+
+	// Mark shots that don't fit the rest of their club's shots, such as a topped iron,
+	// on the distance each club is targeted on
+	if *excludeOutliers && *outliers == "" {
+		*outliers = "iqr"
+	}
+	var detector calculators.OutlierDetector
+	if *outliers != "" {
+		detector, err = calculators.ParseOutlierDetector(*outliers)
+		if err != nil {
+			logging.Fatal(fmt.Sprintf("Error: Invalid outlier detector. Supported detectors are %s.", strings.Join(calculators.OutlierDetectors, ", ")), logging.Fields{
+				"providedOutliers": *outliers,
+				"error":            err.Error(),
+			})
+		}
+		logging.Info("Detected outliers", logging.Fields{
+			"outliers":        calculators.DetectOutliers(shotData, detector, targetOptions),
+			"detector":        detector.String(),
+			"excludeOutliers": *excludeOutliers,
+		})
+	}
+
+	if err := calculators.CalculateTargetsWithOptions(&shotData, targetOptions); err != nil {
+		logging.Fatal(fmt.Sprintf("Error: Invalid distance. Supported distances are %s.", strings.Join(writer.Distances, ", ")), logging.Fields{
+			"providedDistance": targetOptions.Distance,
+			"error":            err.Error(),
+		})
+	}
 
 	logging.Debug("Calculated targets", logging.Fields{
 		"shotData": shotData,
 	})
 
-	// Write processed data to an output file
-	outputFile := utils.ReplaceFileExtension(*inputFile, "_processed.csv")
-	writer := writer.ShotPatternWriter{}
-	if err := writer.Write(outputFile, shotData); err != nil {
-		logging.Error("Error writing output file", err, logging.Fields{
-			"outputFile": outputFile,
+	// Convert the yards and mph shots are read in into the requested unit system
+	if err := processors.ConvertUnits(shotData, *units); err != nil {
+		logging.Fatal(fmt.Sprintf("Error: Invalid units. Supported units are %s.", strings.Join(models.UnitSystems, ", ")), logging.Fields{
+			"providedUnits": *units,
+		})
+	}
+	session.Units = strings.ToLower(*units)
+
+	// Write processed data to an output file for each requested format
+	metadata := writer.Metadata{
+		Session:        session,
+		Distance:       targetOptions.Distance,
+		TargetStrategy: strategy.String(),
+	}
+	if detector != nil {
+		metadata.OutlierDetector = detector.String()
+		metadata.ExcludeOutliers = *excludeOutliers
+	}
+	if len(targetOptions.TypeDistances) > 0 {
+		metadata.TypeDistances = targetOptions.TypeDistances
+	}
+	if len(targetOptions.ClubDistances) > 0 {
+		metadata.ClubDistances = targetOptions.ClubDistances
+	}
+	for club, clubStrategy := range clubStrategies {
+		if metadata.ClubTargetStrategies == nil {
+			metadata.ClubTargetStrategies = make(map[string]string)
+		}
+		metadata.ClubTargetStrategies[club] = clubStrategy.String()
+	}
+	outputs, err := writer.NewOutputs(*format, *inputFile, metadata)
+	if err != nil {
+		logging.Fatal(fmt.Sprintf("Error: Invalid output format. Supported formats are %s.", strings.Join(writer.Formats, ", ")), logging.Fields{
+			"providedFormat": *format,
+			"error":          err.Error(),
+		})
+	}
+	if *outputFile != "" {
+		if len(outputs) > 1 {
+			logging.Fatal("Error: -output can only be used with a single output format.", logging.Fields{
+				"providedFormat": *format,
+			})
+		}
+		outputs[0].Filename = *outputFile
+	}
+
+	// Keep writing the remaining formats when one fails so a single bad output doesn't lose the rest
+	failed := 0
+	for _, output := range outputs {
+		if err := output.Write(shotData); err != nil {
+			logging.Error("Error writing output file", err, logging.Fields{
+				"format":     output.Format,
+				"outputFile": output.Filename,
+			})
+			failed++
+			continue
+		}
+		logging.Info("Wrote output file", logging.Fields{
+			"format":     output.Format,
+			"outputFile": output.Filename,
+		})
+	}
+	if failed > 0 {
+		logging.Info("Finished with output errors", logging.Fields{
+			"outputsWritten": len(outputs) - failed,
+			"outputsFailed":  failed,
 		})
 		os.Exit(1)
 	}
 
 	logging.Info("Successfully processed shots and saved results", logging.Fields{
 		"shotsProcessed": len(shotData),
-		"outputFile":     outputFile,
+		"outputsWritten": len(outputs),
 	})
 }
 
-// normalizeLaunchMonitorType converts the launch monitor type to lowercase for consistency.
-// This ensures that the type check is case-insensitive.
-func normalizeLaunchMonitorType(launchMonitorType string) string {
-	return strings.ToLower(launchMonitorType)
+// resolveLaunchMonitorType returns the registered name of a launch monitor type, registering
+// the profile when one is given and detecting the type from the input file when it isn't given
+func resolveLaunchMonitorType(launchMonitorType, profileFile, inputFile string, options parsers.Options) (string, error) {
+	if profileFile != "" {
+		profile, err := reader.LoadProfile(profileFile)
+		if err != nil {
+			return "", fmt.Errorf("loading launch monitor profile: %w", err)
+		}
+		for _, name := range append([]string{profile.Name}, profile.Aliases...) {
+			if _, exists := reader.Lookup(name); exists {
+				return "", fmt.Errorf("launch monitor profile name '%s' is already registered", name)
+			}
+		}
+		reader.Register(profile.Registration())
+		if launchMonitorType == "" {
+			launchMonitorType = profile.Name
+		}
+	}
+
+	if launchMonitorType == "" {
+		detection, err := parsers.DetectLaunchMonitorTypeWithOptions(inputFile, options)
+		if err != nil {
+			return "", fmt.Errorf("detecting launch monitor type, use -type to specify it: %w", err)
+		}
+		logging.Info("Detected launch monitor type", logging.Fields{
+			"launchMonitorType": detection.LaunchMonitorType,
+			"confidence":        detection.Confidence,
+		})
+		launchMonitorType = detection.LaunchMonitorType
+	}
+
+	registration, ok := reader.Lookup(launchMonitorType)
+	if !ok {
+		return "", fmt.Errorf("invalid launch monitor type '%s': supported types are %s", launchMonitorType, strings.Join(reader.Names(), ", "))
+	}
+	return registration.Name, nil
 }
 
-// isValidLaunchMonitorType checks if the provided launch monitor type is supported.
-// Currently, only "mlm2pro" is supported.
-func isValidLaunchMonitorType(launchMonitorType string) bool {
-	return launchMonitorType == "mlm2pro"
+// writeDiagnostics writes the rows that could not be read to a CSV file, which
+// holds only a header when every row was read.
+func writeDiagnostics(filename string, diagnostics []parsers.Diagnostic) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("creating file '%s': %w", filename, err)
+	}
+	if err := parsers.WriteDiagnostics(file, diagnostics); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// listLaunchMonitors writes a table of the registered launch monitors,
+// their aliases and descriptions to the given writer.
+func listLaunchMonitors(output io.Writer) error {
+	table := tabwriter.NewWriter(output, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "TYPE\tALIASES\tDESCRIPTION")
+	for _, registration := range reader.Registrations() {
+		fmt.Fprintf(table, "%s\t%s\t%s\n", registration.Name, strings.Join(registration.Aliases, ", "), registration.Description)
+	}
+	return table.Flush()
 }
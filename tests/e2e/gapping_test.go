@@ -0,0 +1,76 @@
+package e2e
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"albatross/internal/models"
+)
+
+func TestGappingCommand(t *testing.T) {
+	inputFile, err := filepath.Abs("../../examples/input/mlm2pro.csv")
+	if err != nil {
+		t.Fatalf("Failed to resolve input file: %v", err)
+	}
+
+	tempDir, err := os.MkdirTemp("", "albatross_test")
+	if err != nil {
+		t.Fatalf("Failed to create temporary test directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	// The command lives in package main, so build the binary and run it as a user would
+	binary := filepath.Join(tempDir, "albatross")
+	build := exec.Command("go", "build", "-o", binary, ".")
+	build.Dir = "../.."
+	if output, err := build.CombinedOutput(); err != nil {
+		t.Fatalf("Failed to build albatross: %v\n%s", err, output)
+	}
+
+	// The launch monitor type is detected from the file
+	var stdout, stderr bytes.Buffer
+	command := exec.Command(binary, "gapping", "-input", inputFile, "-format", "json")
+	command.Stdout, command.Stderr = &stdout, &stderr
+	if err := command.Run(); err != nil {
+		t.Fatalf("Gapping command failed: %v\n%s", err, stderr.String())
+	}
+
+	var gapping models.Gapping
+	if err := json.Unmarshal(stdout.Bytes(), &gapping); err != nil {
+		t.Fatalf("Gapping output is not valid JSON: %v\n%s", err, stdout.String())
+	}
+
+	// MLM2Pro reports carry for every club, so gaps are judged on carry
+	if gapping.Distance != "carry" {
+		t.Errorf("Gapping distance = %q, want carry", gapping.Distance)
+	}
+	var clubs []string
+	for _, club := range gapping.Clubs {
+		clubs = append(clubs, club.Club)
+	}
+	expectedClubs := []string{"Dr", "3W", "4Hy", "5i", "6i", "7i", "8i", "9i", "Pw"}
+	if !reflect.DeepEqual(clubs, expectedClubs) {
+		t.Errorf("Gapping clubs = %v, want %v", clubs, expectedClubs)
+	}
+	if len(gapping.Gaps) != len(expectedClubs)-1 {
+		t.Fatalf("Expected %d gaps, got %d", len(expectedClubs)-1, len(gapping.Gaps))
+	}
+	for i, gap := range gapping.Gaps {
+		if gap.Longer != expectedClubs[i] || gap.Shorter != expectedClubs[i+1] {
+			t.Errorf("Gap %d is between %s and %s, want %s and %s", i, gap.Longer, gap.Shorter, expectedClubs[i], expectedClubs[i+1])
+		}
+	}
+	if hole := gapping.Gaps[2]; !hole.Hole || hole.Shorter != "5i" {
+		t.Errorf("Expected a hole above the 5i, got %+v", hole)
+	}
+
+	// Without an input file the command fails
+	if err := exec.Command(binary, "gapping").Run(); err == nil {
+		t.Errorf("Expected the gapping command to fail without an input file")
+	}
+}
@@ -21,6 +21,11 @@ func TestMLM2ProToShotPattern(t *testing.T) {
 			inputFile:      "../../examples/input/mlm2pro.csv",
 			expectedOutput: "../../examples/expected_output/mlm2pro_processed.csv",
 		},
+		{
+			name:           "MLM2Pro XLSX to ShotPattern Sample 1",
+			inputFile:      "../../examples/input/mlm2pro.xlsx",
+			expectedOutput: "../../examples/expected_output/mlm2pro_processed.csv",
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,57 @@
+package e2e
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"albatross/internal/calculators"
+	"albatross/internal/parsers"
+	"albatross/internal/writer"
+)
+
+func TestShotPatternRoundTrip(t *testing.T) {
+	processedFile := "../../examples/expected_output/mlm2pro_processed.csv"
+
+	// Detect the processed file as ShotPattern output
+	detection, err := parsers.DetectLaunchMonitorType(processedFile)
+	if err != nil {
+		t.Fatalf("Failed to detect processed file type: %v", err)
+	}
+	if detection.LaunchMonitorType != "shotpattern" {
+		t.Fatalf("Detected %q, want shotpattern", detection.LaunchMonitorType)
+	}
+
+	// Read the processed file back in and re-target it
+	shotData, err := parsers.ProcessShotData(processedFile, detection.LaunchMonitorType)
+	if err != nil {
+		t.Fatalf("Failed to process ShotPattern data: %v", err)
+	}
+	calculators.CalculateTargets(&shotData)
+
+	tempDir, err := os.MkdirTemp("", "albatross_test")
+	if err != nil {
+		t.Fatalf("Failed to create temporary test directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	outputFile := filepath.Join(tempDir, "round_trip.csv")
+	writer := writer.ShotPatternWriter{}
+	if err := writer.Write(outputFile, shotData); err != nil {
+		t.Fatalf("Failed to write ShotPattern data to output file '%s': %v", outputFile, err)
+	}
+
+	// Writing the re-read data must reproduce the original file exactly
+	actualContent, err := os.ReadFile(outputFile)
+	if err != nil {
+		t.Fatalf("Failed to read round trip output file '%s': %v", outputFile, err)
+	}
+	expectedContent, err := os.ReadFile(processedFile)
+	if err != nil {
+		t.Fatalf("Failed to read processed file '%s': %v", processedFile, err)
+	}
+
+	if string(actualContent) != string(expectedContent) {
+		t.Errorf("Round trip output mismatch.\nExpected:\n%s\nGot:\n%s", expectedContent, actualContent)
+	}
+}
@@ -3,7 +3,7 @@ package utils
 import (
 	"encoding/csv"
 	"fmt"
-	"os"
+	"io"
 	"path/filepath"
 	"strings"
 
@@ -16,23 +16,36 @@ func ReplaceFileExtension(filename, newSuffix string) string {
 	return base + newSuffix
 }
 
-// WriteCSV writes processed shot data to a CSV file
-func WriteCSV(filename string, data []models.ProcessedShotData) error {
+// WriteCSVTo writes processed shot data as CSV to any io.Writer. A Flag column giving
+// why shots look like launch monitor misreads is added when any shot has been flagged,
+// an Outlier column giving why shots are outliers when any shot is one, and a Distance
+// column giving whether Total holds each shot's carry or total when any shot's is carry.
+func WriteCSVTo(output io.Writer, data []models.ProcessedShotData) error {
 	if len(data) == 0 {
 		return fmt.Errorf("no data to write")
 	}
 
-	file, err := os.Create(filename)
-	if err != nil {
-		return fmt.Errorf("creating file: %w", err)
-	}
-	defer file.Close()
+	writer := csv.NewWriter(output)
 
-	writer := csv.NewWriter(file)
-	defer writer.Flush()
+	flagged, outliers, carried := false, false, false
+	for _, d := range data {
+		flagged = flagged || len(d.Flags) > 0
+		outliers = outliers || d.Outlier != ""
+		carried = carried || d.TargetDistance == "carry"
+	}
 
 	// Write header
-	if err := writer.Write([]string{"Club", "Type", "Target", "Total", "Side"}); err != nil {
+	header := []string{"Club", "Type", "Target", "Total", "Side"}
+	if flagged {
+		header = append(header, "Flag")
+	}
+	if outliers {
+		header = append(header, "Outlier")
+	}
+	if carried {
+		header = append(header, "Distance")
+	}
+	if err := writer.Write(header); err != nil {
 		return fmt.Errorf("writing header: %w", err)
 	}
 
@@ -45,9 +58,27 @@ func WriteCSV(filename string, data []models.ProcessedShotData) error {
 			fmt.Sprintf("%.2f", d.Total),
 			fmt.Sprintf("%.2f", d.Side),
 		}
+		if flagged {
+			record = append(record, strings.Join(d.Flags, models.FlagSeparator))
+		}
+		if outliers {
+			record = append(record, d.Outlier)
+		}
+		if carried {
+			distance := d.TargetDistance
+			if distance == "" {
+				distance = "total"
+			}
+			record = append(record, distance)
+		}
 		if err := writer.Write(record); err != nil {
 			return fmt.Errorf("writing record: %w", err)
 		}
 	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("flushing records: %w", err)
+	}
 	return nil
 }
@@ -1,7 +1,8 @@
 package utils
 
 import (
-	"os"
+	"bytes"
+	"errors"
 	"testing"
 
 	"albatross/internal/models"
@@ -30,66 +31,61 @@ func TestReplaceFileExtension(t *testing.T) {
 	}
 }
 
-func TestWriteCSV(t *testing.T) {
-	testFile := "test_output.csv"
+// failingWriter is an io.Writer that always fails
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestWriteCSVTo(t *testing.T) {
 	testData := []models.ProcessedShotData{
 		{Club: "Dr", Type: "Tee", Target: 250.0, Total: 260.0, Side: 5.0},
-		{Club: "7i", Type: "Approach", Target: 150.0, Total: 155.0, Side: -2.0},
 	}
 
-	// Clean up the test file after the test
-	defer os.Remove(testFile)
-
-	err := WriteCSV(testFile, testData)
-	if err != nil {
-		t.Fatalf("WriteCSV failed: %v", err)
+	var buffer bytes.Buffer
+	if err := WriteCSVTo(&buffer, testData); err != nil {
+		t.Fatalf("WriteCSVTo failed: %v", err)
 	}
-
-	// Check if file was created
-	_, err = os.Stat(testFile)
-	if os.IsNotExist(err) {
-		t.Fatalf("Output file was not created")
+	expectedContent := "Club,Type,Target,Total,Side\nDr,Tee,250.00,260.00,5.00\n"
+	if buffer.String() != expectedContent {
+		t.Errorf("Content mismatch.\nExpected:\n%s\nGot:\n%s", expectedContent, buffer.String())
 	}
 
-	// Read the contents of the file and verify
-	content, err := os.ReadFile(testFile)
-	if err != nil {
-		t.Fatalf("Failed to read output file: %v", err)
+	buffer.Reset()
+	flaggedData := append(testData, models.ProcessedShotData{Club: "Dr", Type: "Tee", Target: 250.0, Total: 180.0, Side: 2.0, Flags: []string{"zero spin", "carry greater than total"}})
+	if err := WriteCSVTo(&buffer, flaggedData); err != nil {
+		t.Fatalf("WriteCSVTo failed: %v", err)
 	}
-
-	expectedContent := `Club,Type,Target,Total,Side
-Dr,Tee,250.00,260.00,5.00
-7i,Approach,150.00,155.00,-2.00
-`
-
-	if string(content) != expectedContent {
-		t.Errorf("File content mismatch.\nExpected:\n%s\nGot:\n%s", expectedContent, string(content))
+	expectedContent = "Club,Type,Target,Total,Side,Flag\nDr,Tee,250.00,260.00,5.00,\nDr,Tee,250.00,180.00,2.00,zero spin; carry greater than total\n"
+	if buffer.String() != expectedContent {
+		t.Errorf("Content mismatch.\nExpected:\n%s\nGot:\n%s", expectedContent, buffer.String())
 	}
 
-	// Test with empty data
-	err = WriteCSV(testFile, []models.ProcessedShotData{})
-	if err == nil {
-		t.Errorf("Expected error for empty data, got nil")
+	buffer.Reset()
+	outlierData := append(testData, models.ProcessedShotData{Club: "Dr", Type: "Tee", Target: 250.0, Total: 180.0, Side: 2.0, Outlier: "total 180.0 below IQR fence 240.0"})
+	if err := WriteCSVTo(&buffer, outlierData); err != nil {
+		t.Fatalf("WriteCSVTo failed: %v", err)
+	}
+	expectedContent = "Club,Type,Target,Total,Side,Outlier\nDr,Tee,250.00,260.00,5.00,\nDr,Tee,250.00,180.00,2.00,total 180.0 below IQR fence 240.0\n"
+	if buffer.String() != expectedContent {
+		t.Errorf("Content mismatch.\nExpected:\n%s\nGot:\n%s", expectedContent, buffer.String())
 	}
-}
 
-func TestWriteCSVErrors(t *testing.T) {
-	tests := []struct {
-		name     string
-		filename string
-		data     []models.ProcessedShotData
-		wantErr  bool
-	}{
-		{"Invalid filename", "/invalid/path/file.csv", []models.ProcessedShotData{{Club: "Dr"}}, true},
-		{"Empty data", "empty.csv", []models.ProcessedShotData{}, true},
+	buffer.Reset()
+	carriedData := append(testData, models.ProcessedShotData{Club: "7i", Type: "Approach", Target: 143.0, Total: 141.5, Side: -2.0, TargetDistance: "carry"})
+	if err := WriteCSVTo(&buffer, carriedData); err != nil {
+		t.Fatalf("WriteCSVTo failed: %v", err)
+	}
+	expectedContent = "Club,Type,Target,Total,Side,Distance\nDr,Tee,250.00,260.00,5.00,total\n7i,Approach,143.00,141.50,-2.00,carry\n"
+	if buffer.String() != expectedContent {
+		t.Errorf("Content mismatch.\nExpected:\n%s\nGot:\n%s", expectedContent, buffer.String())
 	}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			err := WriteCSV(tt.filename, tt.data)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("WriteCSV() error = %v, wantErr %v", err, tt.wantErr)
-			}
-		})
+	if err := WriteCSVTo(failingWriter{}, testData); err == nil {
+		t.Errorf("Expected error from a failing writer, got nil")
+	}
+	if err := WriteCSVTo(&buffer, nil); err == nil {
+		t.Errorf("Expected error for empty data, got nil")
 	}
 }